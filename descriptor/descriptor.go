@@ -0,0 +1,115 @@
+// Package descriptor converts the parsed proto to the descriptor form used by the official protobuf toolchain.
+package descriptor
+
+import (
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+// FromProto converts *parser.Proto to *descriptorpb.FileDescriptorProto.
+//
+// The imports, the messages, the fields with their labels and types, the map fields, the groups, the oneofs,
+// the reserved statements, the extensions, the enums and the services are converted.
+// A scalar type is mapped to its FieldDescriptorProto_Type, and a message or an enum type defined in the proto
+// is resolved to its fully-qualified name. A type which isn't defined in the proto, like the one defined in
+// an imported file, is kept in TypeName as written with no Type, which the descriptor allows.
+//
+// The options, the extends and the comments aren't converted, except the map_entry option of a map entry.
+func FromProto(p *parser.Proto) (*descriptorpb.FileDescriptorProto, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	// The undefined types are kept as written, so the errors don't matter here.
+	scope, _ := parser.Resolve(p)
+	c := &converter{
+		scope:  scope,
+		names:  make(map[parser.Visitee]string),
+		proto3: p.SyntaxVersion() == "proto3",
+	}
+	for name, symbol := range scope.Symbols {
+		c.names[symbol] = "." + name
+	}
+
+	file := &descriptorpb.FileDescriptorProto{
+		Syntax: proto.String(p.SyntaxVersion()),
+	}
+	if p.Meta != nil && p.Meta.Filename != "" {
+		file.Name = proto.String(p.Meta.Filename)
+	}
+	pkg := p.PackageName()
+	if pkg != "" {
+		file.Package = proto.String(pkg)
+	}
+
+	for i, imp := range p.Imports() {
+		file.Dependency = append(file.Dependency, imp.UnquotedLocation())
+		switch imp.Modifier {
+		case parser.ImportModifierPublic:
+			file.PublicDependency = append(file.PublicDependency, int32(i))
+		case parser.ImportModifierWeak:
+			file.WeakDependency = append(file.WeakDependency, int32(i))
+		}
+	}
+
+	for _, element := range p.ProtoBody {
+		switch e := element.(type) {
+		case *parser.Message:
+			message, err := c.message(qualifyName(pkg, e.MessageName), e.MessageName, e.MessageBody)
+			if err != nil {
+				return nil, err
+			}
+			file.MessageType = append(file.MessageType, message)
+		case *parser.Enum:
+			enum, err := c.enum(e)
+			if err != nil {
+				return nil, err
+			}
+			file.EnumType = append(file.EnumType, enum)
+		case *parser.Service:
+			service, err := c.service(e)
+			if err != nil {
+				return nil, err
+			}
+			file.Service = append(file.Service, service)
+		}
+	}
+	return file, nil
+}
+
+type converter struct {
+	scope *parser.Scope
+	// names maps the declarations to their fully-qualified names with the leading dot.
+	names  map[parser.Visitee]string
+	proto3 bool
+}
+
+// typeName returns the fully-qualified name of the type which the element refers to,
+// or the type name as written when it's unresolved.
+func (c *converter) typeName(element interface{}, typ string) (string, parser.Visitee) {
+	if symbol, ok := c.scope.References[element]; ok {
+		return c.names[symbol], symbol
+	}
+	return typ, nil
+}
+
+// number parses the field number or the enum value number of the named declaration.
+func parseNumber(name string, value string) (int32, error) {
+	n, err := strconv.ParseInt(value, 0, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %v of %v: %v", value, name, err)
+	}
+	return int32(n), nil
+}
+
+func qualifyName(scope string, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
@@ -0,0 +1,189 @@
+package descriptor_test
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/yoheimuta/go-protoparser/v4/descriptor"
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestFromProto(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		// wantDescriptor is the text format of the FileDescriptorProto.
+		wantDescriptor string
+		wantErr        bool
+	}{
+		{
+			name: "converting the imports, the messages, the enums and the services",
+			input: `syntax = "proto3";
+package pkg;
+import "a.proto";
+import public "b.proto";
+message Foo {
+  message Bar {
+    Color color = 1;
+  }
+  enum Color {
+    RED = 0;
+    reserved 2 to max;
+    reserved "BLUE";
+  }
+  repeated Bar bars = 1;
+  .pkg.Foo.Bar bar = 2;
+  optional string user_name = 3;
+  other.Baz baz = 4;
+  oneof choice {
+    int64 id = 5;
+    Color color = 6;
+  }
+  map<string, Bar> bar_by_name = 7;
+  reserved 10 to 20, 100 to max;
+  reserved "old";
+}
+service FooService {
+  rpc Get(Foo) returns (Foo.Bar);
+  rpc Watch(stream Foo) returns (stream Foo.Bar);
+}
+`,
+			wantDescriptor: `
+syntax: "proto3"
+package: "pkg"
+dependency: ["a.proto", "b.proto"]
+public_dependency: [1]
+message_type {
+  name: "Foo"
+  field { name: "bars" number: 1 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".pkg.Foo.Bar" json_name: "bars" }
+  field { name: "bar" number: 2 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".pkg.Foo.Bar" json_name: "bar" }
+  field {
+    name: "user_name" number: 3 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "userName"
+    oneof_index: 1 proto3_optional: true
+  }
+  field { name: "baz" number: 4 label: LABEL_OPTIONAL type_name: "other.Baz" json_name: "baz" }
+  field { name: "id" number: 5 label: LABEL_OPTIONAL type: TYPE_INT64 json_name: "id" oneof_index: 0 }
+  field {
+    name: "color" number: 6 label: LABEL_OPTIONAL type: TYPE_ENUM type_name: ".pkg.Foo.Color" json_name: "color"
+    oneof_index: 0
+  }
+  field {
+    name: "bar_by_name" number: 7 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".pkg.Foo.BarByNameEntry"
+    json_name: "barByName"
+  }
+  nested_type {
+    name: "Bar"
+    field {
+      name: "color" number: 1 label: LABEL_OPTIONAL type: TYPE_ENUM type_name: ".pkg.Foo.Color" json_name: "color"
+    }
+  }
+  nested_type {
+    name: "BarByNameEntry"
+    field { name: "key" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "key" }
+    field { name: "value" number: 2 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".pkg.Foo.Bar" json_name: "value" }
+    options { map_entry: true }
+  }
+  enum_type {
+    name: "Color"
+    value { name: "RED" number: 0 }
+    reserved_range { start: 2 end: 2147483647 }
+    reserved_name: "BLUE"
+  }
+  oneof_decl { name: "choice" }
+  oneof_decl { name: "_user_name" }
+  reserved_range { start: 10 end: 21 }
+  reserved_range { start: 100 end: 536870912 }
+  reserved_name: "old"
+}
+service {
+  name: "FooService"
+  method { name: "Get" input_type: ".pkg.Foo" output_type: ".pkg.Foo.Bar" }
+  method {
+    name: "Watch" input_type: ".pkg.Foo" output_type: ".pkg.Foo.Bar"
+    client_streaming: true server_streaming: true
+  }
+}
+`,
+		},
+		{
+			name: "converting the proto2 labels, the groups and the extensions",
+			input: `syntax = "proto2";
+message Foo {
+  required int32 a = 1;
+  optional sint64 b = 0x2;
+  repeated group Result = 3 {
+    optional string url = 4;
+  }
+  extensions 100 to 199;
+}
+enum Sign {
+  MINUS = -1;
+}
+`,
+			wantDescriptor: `
+syntax: "proto2"
+message_type {
+  name: "Foo"
+  field { name: "a" number: 1 label: LABEL_REQUIRED type: TYPE_INT32 json_name: "a" }
+  field { name: "b" number: 2 label: LABEL_OPTIONAL type: TYPE_SINT64 json_name: "b" }
+  field { name: "result" number: 3 label: LABEL_REPEATED type: TYPE_GROUP type_name: ".Foo.Result" json_name: "result" }
+  nested_type {
+    name: "Result"
+    field { name: "url" number: 4 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "url" }
+  }
+  extension_range { start: 100 end: 200 }
+}
+enum_type {
+  name: "Sign"
+  value { name: "MINUS" number: -1 }
+}
+`,
+		},
+		{
+			name: "converting a field number out of the range",
+			input: `syntax = "proto3";
+message Foo {
+  int32 a = 4294967296;
+}
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)), parser.WithPermissive(true))
+			parsed, err := p.ParseProto()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := descriptor.FromProto(parsed)
+			switch {
+			case test.wantErr:
+				if err == nil {
+					t.Errorf("got err nil, but want err")
+				}
+				return
+			case !test.wantErr && err != nil:
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			want := &descriptorpb.FileDescriptorProto{}
+			err = prototext.Unmarshal([]byte(test.wantDescriptor), want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(got, want) {
+				t.Errorf("got %v, but want %v", prototext.Format(got), prototext.Format(want))
+			}
+		})
+	}
+}
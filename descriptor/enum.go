@@ -0,0 +1,46 @@
+package descriptor
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func (c *converter) enum(e *parser.Enum) (*descriptorpb.EnumDescriptorProto, error) {
+	enum := &descriptorpb.EnumDescriptorProto{
+		Name: proto.String(e.EnumName),
+	}
+	for _, element := range e.EnumBody {
+		switch b := element.(type) {
+		case *parser.EnumField:
+			n, err := parseNumber(b.Ident, b.Number)
+			if err != nil {
+				return nil, err
+			}
+			enum.Value = append(enum.Value, &descriptorpb.EnumValueDescriptorProto{
+				Name:   proto.String(b.Ident),
+				Number: proto.Int32(n),
+			})
+		case *parser.Reserved:
+			for _, r := range b.Ranges {
+				begin, end, err := r.Bounds()
+				if err != nil {
+					return nil, err
+				}
+				// Unlike the message, the end of the range is inclusive and max is the maximum int32.
+				if r.IsMax() {
+					end = math.MaxInt32
+				}
+				enum.ReservedRange = append(enum.ReservedRange, &descriptorpb.EnumDescriptorProto_EnumReservedRange{
+					Start: proto.Int32(int32(begin)),
+					End:   proto.Int32(int32(end)),
+				})
+			}
+			enum.ReservedName = append(enum.ReservedName, b.UnquotedFieldNames()...)
+		}
+	}
+	return enum, nil
+}
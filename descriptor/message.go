@@ -0,0 +1,255 @@
+package descriptor
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+// message converts the message or the group body whose fully-qualified name is fullName.
+func (c *converter) message(fullName string, name string, body []parser.Visitee) (
+	*descriptorpb.DescriptorProto,
+	error,
+) {
+	message := &descriptorpb.DescriptorProto{
+		Name: proto.String(name),
+	}
+	// proto3Optionals are the fields with the proto3 optional label, each of which gets a synthetic oneof
+	// following the declared ones.
+	var proto3Optionals []*descriptorpb.FieldDescriptorProto
+	for _, element := range body {
+		switch e := element.(type) {
+		case *parser.Field:
+			field, err := c.field(e, e.FieldName, e.FieldNumber, e.Type)
+			if err != nil {
+				return nil, err
+			}
+			field.Label = label(e.IsRepeated, e.IsRequired)
+			if e.IsOptional && c.proto3 {
+				field.Proto3Optional = proto.Bool(true)
+				proto3Optionals = append(proto3Optionals, field)
+			}
+			message.Field = append(message.Field, field)
+		case *parser.MapField:
+			entry, err := c.mapEntry(e)
+			if err != nil {
+				return nil, err
+			}
+			message.NestedType = append(message.NestedType, entry)
+
+			field, err := c.field(e, e.MapName, e.FieldNumber, "")
+			if err != nil {
+				return nil, err
+			}
+			field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+			field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+			field.TypeName = proto.String("." + fullName + "." + entry.GetName())
+			message.Field = append(message.Field, field)
+		case *parser.GroupField:
+			groupName := fullName + "." + e.GroupName
+			group, err := c.message(groupName, e.GroupName, e.MessageBody)
+			if err != nil {
+				return nil, err
+			}
+			message.NestedType = append(message.NestedType, group)
+
+			field, err := c.field(e, strings.ToLower(e.GroupName), e.FieldNumber, "")
+			if err != nil {
+				return nil, err
+			}
+			field.Label = label(e.IsRepeated, e.IsRequired)
+			field.Type = descriptorpb.FieldDescriptorProto_TYPE_GROUP.Enum()
+			field.TypeName = proto.String("." + groupName)
+			message.Field = append(message.Field, field)
+		case *parser.Oneof:
+			index := int32(len(message.OneofDecl))
+			message.OneofDecl = append(message.OneofDecl, &descriptorpb.OneofDescriptorProto{
+				Name: proto.String(e.OneofName),
+			})
+			for _, oneofField := range e.OneofFields {
+				field, err := c.field(oneofField, oneofField.FieldName, oneofField.FieldNumber, oneofField.Type)
+				if err != nil {
+					return nil, err
+				}
+				field.Label = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+				field.OneofIndex = proto.Int32(index)
+				message.Field = append(message.Field, field)
+			}
+		case *parser.Message:
+			nested, err := c.message(fullName+"."+e.MessageName, e.MessageName, e.MessageBody)
+			if err != nil {
+				return nil, err
+			}
+			message.NestedType = append(message.NestedType, nested)
+		case *parser.Enum:
+			enum, err := c.enum(e)
+			if err != nil {
+				return nil, err
+			}
+			message.EnumType = append(message.EnumType, enum)
+		case *parser.Reserved:
+			for _, r := range e.Ranges {
+				begin, end, err := r.Bounds()
+				if err != nil {
+					return nil, err
+				}
+				// The end of the range is exclusive in the descriptor.
+				message.ReservedRange = append(message.ReservedRange, &descriptorpb.DescriptorProto_ReservedRange{
+					Start: proto.Int32(int32(begin)),
+					End:   proto.Int32(int32(end + 1)),
+				})
+			}
+			message.ReservedName = append(message.ReservedName, e.UnquotedFieldNames()...)
+		case *parser.Extensions:
+			for _, r := range e.Ranges {
+				begin, end, err := r.Bounds()
+				if err != nil {
+					return nil, err
+				}
+				message.ExtensionRange = append(message.ExtensionRange, &descriptorpb.DescriptorProto_ExtensionRange{
+					Start: proto.Int32(int32(begin)),
+					End:   proto.Int32(int32(end + 1)),
+				})
+			}
+		}
+	}
+
+	for _, field := range proto3Optionals {
+		field.OneofIndex = proto.Int32(int32(len(message.OneofDecl)))
+		message.OneofDecl = append(message.OneofDecl, &descriptorpb.OneofDescriptorProto{
+			Name: proto.String("_" + field.GetName()),
+		})
+	}
+	return message, nil
+}
+
+// field converts the field except its label. An empty typ leaves the type to the caller.
+func (c *converter) field(element interface{}, name string, number string, typ string) (
+	*descriptorpb.FieldDescriptorProto,
+	error,
+) {
+	n, err := parseNumber(name, number)
+	if err != nil {
+		return nil, err
+	}
+	field := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		Number:   proto.Int32(n),
+		JsonName: proto.String(jsonName(name)),
+	}
+	if typ != "" {
+		c.setType(field, element, typ)
+	}
+	return field, nil
+}
+
+// setType sets the type of the field, which the element refers to by typ.
+func (c *converter) setType(field *descriptorpb.FieldDescriptorProto, element interface{}, typ string) {
+	if scalar, ok := scalarTypes[typ]; ok {
+		field.Type = scalar.Enum()
+		return
+	}
+
+	typeName, symbol := c.typeName(element, typ)
+	field.TypeName = proto.String(typeName)
+	switch symbol.(type) {
+	case *parser.Enum:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum()
+	case *parser.Message, *parser.GroupField:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+	}
+}
+
+// mapEntry returns the nested type which protoc generates for the map field.
+func (c *converter) mapEntry(mapField *parser.MapField) (*descriptorpb.DescriptorProto, error) {
+	key := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String("key"),
+		Number:   proto.Int32(1),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		JsonName: proto.String("key"),
+	}
+	keyType, ok := scalarTypes[mapField.KeyType]
+	if !ok {
+		return nil, fmt.Errorf("invalid key type %v of %v", mapField.KeyType, mapField.MapName)
+	}
+	key.Type = keyType.Enum()
+
+	value := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String("value"),
+		Number:   proto.Int32(2),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		JsonName: proto.String("value"),
+	}
+	c.setType(value, mapField, mapField.Type)
+
+	return &descriptorpb.DescriptorProto{
+		Name:  proto.String(mapEntryName(mapField.MapName)),
+		Field: []*descriptorpb.FieldDescriptorProto{key, value},
+		Options: &descriptorpb.MessageOptions{
+			MapEntry: proto.Bool(true),
+		},
+	}, nil
+}
+
+func label(isRepeated, isRequired bool) *descriptorpb.FieldDescriptorProto_Label {
+	switch {
+	case isRepeated:
+		return descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	case isRequired:
+		return descriptorpb.FieldDescriptorProto_LABEL_REQUIRED.Enum()
+	default:
+		return descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	}
+}
+
+// jsonName returns the JSON name of the field like protoc, which drops the underscores
+// and capitalizes the letters following them.
+func jsonName(name string) string {
+	return camelCase(name, false)
+}
+
+// mapEntryName returns the name of the map entry like protoc, which is the camel-cased field name
+// followed by "Entry".
+func mapEntryName(name string) string {
+	return camelCase(name, true) + "Entry"
+}
+
+func camelCase(name string, capitalizeFirst bool) string {
+	var b strings.Builder
+	capitalizeNext := capitalizeFirst
+	for _, r := range name {
+		switch {
+		case r == '_':
+			capitalizeNext = true
+		case capitalizeNext:
+			b.WriteString(strings.ToUpper(string(r)))
+			capitalizeNext = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// scalarTypes maps the scalar types to their types in the descriptor.
+var scalarTypes = map[string]descriptorpb.FieldDescriptorProto_Type{
+	"double":   descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+	"float":    descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
+	"int64":    descriptorpb.FieldDescriptorProto_TYPE_INT64,
+	"uint64":   descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+	"int32":    descriptorpb.FieldDescriptorProto_TYPE_INT32,
+	"fixed64":  descriptorpb.FieldDescriptorProto_TYPE_FIXED64,
+	"fixed32":  descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
+	"bool":     descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+	"string":   descriptorpb.FieldDescriptorProto_TYPE_STRING,
+	"bytes":    descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+	"uint32":   descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+	"sfixed32": descriptorpb.FieldDescriptorProto_TYPE_SFIXED32,
+	"sfixed64": descriptorpb.FieldDescriptorProto_TYPE_SFIXED64,
+	"sint32":   descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+	"sint64":   descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+}
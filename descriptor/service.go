@@ -0,0 +1,35 @@
+package descriptor
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func (c *converter) service(s *parser.Service) (*descriptorpb.ServiceDescriptorProto, error) {
+	service := &descriptorpb.ServiceDescriptorProto{
+		Name: proto.String(s.ServiceName),
+	}
+	for _, element := range s.ServiceBody {
+		rpc, ok := element.(*parser.RPC)
+		if !ok {
+			continue
+		}
+		inputType, _ := c.typeName(rpc.RPCRequest, rpc.RPCRequest.MessageType)
+		outputType, _ := c.typeName(rpc.RPCResponse, rpc.RPCResponse.MessageType)
+		method := &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(rpc.RPCName),
+			InputType:  proto.String(inputType),
+			OutputType: proto.String(outputType),
+		}
+		if rpc.RPCRequest.IsStream {
+			method.ClientStreaming = proto.Bool(true)
+		}
+		if rpc.RPCResponse.IsStream {
+			method.ServerStreaming = proto.Bool(true)
+		}
+		service.Method = append(service.Method, method)
+	}
+	return service, nil
+}
@@ -0,0 +1,40 @@
+//go:build go1.18
+// +build go1.18
+
+package protoparser_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"testing"
+
+	protoparser "github.com/yoheimuta/go-protoparser/v4"
+)
+
+func FuzzParse(f *testing.F) {
+	paths, err := filepath.Glob("_testdata/*.proto")
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(content)
+	}
+	// The lexer logs each error it encounters, which floods the output while fuzzing.
+	output := log.Writer()
+	log.SetOutput(ioutil.Discard)
+	f.Cleanup(func() {
+		log.SetOutput(output)
+	})
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		// Parse must return either an error or a Proto without panicking.
+		_, _ = protoparser.Parse(bytes.NewReader(input))
+		_, _ = protoparser.Parse(bytes.NewReader(input), protoparser.WithPermissive(false))
+	})
+}
@@ -1,3 +1,5 @@
 module github.com/yoheimuta/go-protoparser/v4
 
 go 1.13
+
+require google.golang.org/protobuf v1.28.1
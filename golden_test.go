@@ -0,0 +1,70 @@
+package protoparser_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	protoparser "github.com/yoheimuta/go-protoparser/v4"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestParse_golden(t *testing.T) {
+	tests := []struct {
+		name       string
+		inputPath  string
+		goldenPath string
+	}{
+		{
+			name:       "parsing a fixture covering every construct",
+			inputPath:  "_testdata/comprehensive.proto",
+			goldenPath: "_testdata/comprehensive.golden.json",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			reader, err := os.Open(test.inputPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer reader.Close()
+
+			got, err := protoparser.Parse(
+				reader,
+				protoparser.WithFilename(filepath.Base(test.inputPath)),
+			)
+			if err != nil {
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			gotJSON, err := json.MarshalIndent(got, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotJSON = append(gotJSON, '\n')
+
+			if *update {
+				err = ioutil.WriteFile(test.goldenPath, gotJSON, 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			wantJSON, err := ioutil.ReadFile(test.goldenPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(gotJSON, wantJSON) {
+				t.Errorf("got %s, but want %s. Run `go test -run TestParse_golden -update .` after checking the diff", gotJSON, wantJSON)
+			}
+		})
+	}
+}
@@ -6,9 +6,18 @@ import (
 	"github.com/yoheimuta/go-protoparser/v4/internal/lexer/scanner"
 )
 
-// ReadConstant reads a constant. If permissive is true, accepts multiline string literals.
-// constant = fullIdent | ( [ "-" | "+" ] intLit ) | ( [ "-" | "+" ] floatLit ) | strLit | boolLit
-func (lex *Lexer) ReadConstant(permissive bool) (string, scanner.Position, error) {
+// ReadConstant reads a constant. Adjacent string literals are concatenated into one.
+// constant = fullIdent | ( [ "-" | "+" ] intLit ) | ( [ "-" | "+" ] floatLit ) | strLit { strLit } | boolLit
+func (lex *Lexer) ReadConstant() (string, scanner.Position, error) {
+	constant, _, pos, err := lex.ReadRawConstant()
+	return constant, pos, err
+}
+
+// ReadRawConstant is like ReadConstant but also returns the raw spelling of the constant.
+// The raw spelling differs from the constant only when adjacent string literals are concatenated,
+// and then it's the source text from the first literal to the last one, including the whitespace
+// and the comments between them.
+func (lex *Lexer) ReadRawConstant() (constant string, raw string, pos scanner.Position, err error) {
 	lex.NextLit()
 
 	startPos := lex.Pos
@@ -16,50 +25,76 @@ func (lex *Lexer) ReadConstant(permissive bool) (string, scanner.Position, error
 
 	switch {
 	case lex.Token == scanner.TSTRLIT:
-		if permissive {
-			return lex.mergeMultilineStrLit(), startPos, nil
-		}
-		return cons, startPos, nil
+		constant, raw := lex.mergeMultilineStrLit()
+		return constant, raw, startPos, nil
 	case lex.Token == scanner.TBOOLLIT:
-		return cons, startPos, nil
+		return cons, cons, startPos, nil
 	case lex.Token == scanner.TIDENT:
 		lex.UnNext()
 		fullIdent, pos, err := lex.ReadFullIdent()
 		if err != nil {
-			return "", scanner.Position{}, err
+			return "", "", scanner.Position{}, err
 		}
-		return fullIdent, pos, nil
+		return fullIdent, fullIdent, pos, nil
 	case lex.Token == scanner.TINTLIT, lex.Token == scanner.TFLOATLIT:
-		return cons, startPos, nil
+		return cons, cons, startPos, nil
 	case lex.Text == "-" || lex.Text == "+":
 		lex.NextLit()
 
 		switch lex.Token {
 		case scanner.TINTLIT, scanner.TFLOATLIT:
 			cons += lex.Text
-			return cons, startPos, nil
+			return cons, cons, startPos, nil
 		default:
-			return "", scanner.Position{}, lex.unexpected(lex.Text, "TINTLIT or TFLOATLIT")
+			return "", "", scanner.Position{}, lex.unexpected(lex.Text, "TINTLIT or TFLOATLIT")
 		}
 	default:
-		return "", scanner.Position{}, lex.unexpected(lex.Text, "constant")
+		return "", "", scanner.Position{}, lex.unexpected(lex.Text, "constant")
 	}
 }
 
-// Merges a multiline string literal into a single string.
-func (lex *Lexer) mergeMultilineStrLit() string {
-	q := "'"
-	if strings.HasPrefix(lex.Text, "\"") {
-		q = "\""
-	}
+// Merges a multiline string literal into a single string, and returns it with the source text of the literals.
+// The merged one keeps the quote of the first literal. The following literals
+// quoted by another quote are re-escaped to fit it.
+func (lex *Lexer) mergeMultilineStrLit() (merged string, raw string) {
+	q := lex.Text[0]
 	var b strings.Builder
-	b.WriteString(q)
+	var literals []string
+	start := lex.Pos.Offset
+	var end int
+	b.WriteByte(q)
 	for lex.Token == scanner.TSTRLIT {
-		strippedString := strings.Trim(lex.Text, q)
+		literals = append(literals, lex.Text)
+		end = lex.Pos.Offset + len(lex.Text)
+		strippedString := lex.Text[1 : len(lex.Text)-1]
+		if lex.Text[0] != q {
+			strippedString = escapeQuote(strippedString, q)
+		}
 		b.WriteString(strippedString)
 		lex.NextLit()
 	}
 	lex.UnNext()
-	b.WriteString(q)
+	b.WriteByte(q)
+
+	raw, ok := lex.scanner.SourceText(start, end)
+	if !ok {
+		// The source text has been released, which happens only before the statement.
+		raw = strings.Join(literals, " ")
+	}
+	return b.String(), raw
+}
+
+// escapeQuote escapes the unescaped quotes q in the string literal body s.
+func escapeQuote(s string, q byte) string {
+	var b strings.Builder
+	escaped := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == q && !escaped {
+			b.WriteByte('\\')
+		}
+		escaped = c == '\\' && !escaped
+		b.WriteByte(c)
+	}
 	return b.String()
 }
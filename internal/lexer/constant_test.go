@@ -96,12 +96,24 @@ func TestLexer2_ReadConstant(t *testing.T) {
 			input:   `"`,
 			wantErr: true,
 		},
+		{
+			name:      "multiline strLit with mixed quotes",
+			input:     "\"line1 \"\n'line2 \"quoted\" ' \n\"line3\" ",
+			wantText:  `"line1 line2 \"quoted\" line3"`,
+			wantIsEOF: true,
+		},
+		{
+			name:      "multiline strLit with escaped quotes",
+			input:     `'it\'s ' 'line2' `,
+			wantText:  `'it\'s line2'`,
+			wantIsEOF: true,
+		},
 	}
 	for _, test := range tests {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
 			lex := lexer.NewLexer(strings.NewReader(test.input))
-			got, pos, err := lex.ReadConstant(true)
+			got, pos, err := lex.ReadConstant()
 
 			switch {
 			case test.wantErr:
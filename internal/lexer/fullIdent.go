@@ -27,5 +27,5 @@ func (lex *Lexer) ReadFullIdent() (string, scanner.Position, error) {
 		fullIdent += "." + lex.Text
 		lex.Next()
 	}
-	return fullIdent, startPos, nil
+	return lex.Intern(fullIdent), startPos, nil
 }
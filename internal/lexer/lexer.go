@@ -50,6 +50,21 @@ func WithFilename(filename string) Option {
 	}
 }
 
+// IsDebug returns true if the debug mode is enabled.
+func (lex *Lexer) IsDebug() bool {
+	return lex.debug
+}
+
+// SetInterning enables or disables the interning of the texts, so that the identical texts share the storage.
+func (lex *Lexer) SetInterning(interning bool) {
+	lex.scanner.SetInterning(interning)
+}
+
+// Intern returns the interned string equal to str when the interning is enabled. Otherwise, it returns str as is.
+func (lex *Lexer) Intern(str string) string {
+	return lex.scanner.Intern(str)
+}
+
 // NewLexer creates a new lexer.
 func NewLexer(input io.Reader, opts ...Option) *Lexer {
 	lex := new(Lexer)
@@ -149,6 +164,21 @@ func (lex *Lexer) LatestErr() error {
 	return lex.scanErr
 }
 
+// LineText returns the text of the line, starting at 1, with keeping the read buffer unchanged.
+func (lex *Lexer) LineText(line int) string {
+	return lex.scanner.LineText(line)
+}
+
+// Source returns the text read so far, which is the whole input once the lexing reaches the end.
+func (lex *Lexer) Source() string {
+	return lex.scanner.Source()
+}
+
+// Release drops the text before the line containing pos, which Source, LineText and Rewind no longer cover.
+func (lex *Lexer) Release(pos scanner.Position) {
+	lex.scanner.Release(pos.Offset)
+}
+
 // Peek returns the next token with keeping the read buffer unchanged.
 func (lex *Lexer) Peek() scanner.Token {
 	lex.Next()
@@ -184,11 +214,22 @@ func (lex *Lexer) UnNextTo(lastScan []rune) {
 	lex.Token = scanner.TILLEGAL
 }
 
-// ConsumeToken consumes a given token if it exists. Otherwise, it consumes no token.
+// Rewind puts the scanned text back to the read buffer until the position returns to pos,
+// which is the position of an earlier token.
+func (lex *Lexer) Rewind(pos scanner.Position) {
+	lex.scanner.Rewind(pos.Offset)
+	lex.Token = scanner.TILLEGAL
+}
+
+// ConsumeToken consumes a given token if it exists. Otherwise, it consumes no token
+// and keeps the latest token, text and position unchanged.
 func (lex *Lexer) ConsumeToken(t scanner.Token) {
+	token, text, pos := lex.Token, lex.Text, lex.Pos
+
 	lex.Next()
 	if lex.Token == t {
 		return
 	}
 	lex.UnNext()
+	lex.Token, lex.Text, lex.Pos = token, text, pos
 }
@@ -33,5 +33,5 @@ func (lex *Lexer) ReadMessageType() (string, scanner.Position, error) {
 		lex.Next()
 	}
 
-	return messageType, startPos, nil
+	return lex.Intern(messageType), startPos, nil
 }
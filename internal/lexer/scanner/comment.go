@@ -1,32 +1,39 @@
 package scanner
 
-// comment = ( "//" { [^\n] } "\n" ) |  ( "/*" { any } "*/" )
+// comment = ( "//" { [^\n] } ( "\n" | "\r\n" ) ) |  ( "/*" { any } "*/" )
+//
+// The comment is built in the reused textBuf, so that it allocates only the resulting string.
 func (s *Scanner) scanComment() (string, error) {
-	lit := string(s.read())
+	s.textBuf = appendRune(s.textBuf[:0], s.read())
 
 	ch := s.read()
 	switch ch {
 	case '/':
 		for ch != '\n' {
-			lit += string(ch)
+			// "\r\n" ends the comment as a single line break, so the comment doesn't include "\r".
+			if ch == '\r' && s.peek() == '\n' {
+				s.read()
+				break
+			}
+			s.textBuf = appendRune(s.textBuf, ch)
 
 			if s.isEOF() {
-				return lit, nil
+				return string(s.textBuf), nil
 			}
 			ch = s.read()
 		}
 	case '*':
 		for {
 			if s.isEOF() {
-				return lit, s.unexpected(eof, "\n")
+				return string(s.textBuf), s.unexpected(eof, "\n")
 			}
-			lit += string(ch)
+			s.textBuf = appendRune(s.textBuf, ch)
 
 			ch = s.read()
 			chn := s.peek()
 			if ch == '*' && chn == '/' {
-				lit += string(ch)
-				lit += string(s.read())
+				s.textBuf = appendRune(s.textBuf, ch)
+				s.textBuf = appendRune(s.textBuf, s.read())
 				break
 			}
 		}
@@ -34,5 +41,5 @@ func (s *Scanner) scanComment() (string, error) {
 		return "", s.unexpected(ch, "/ or *")
 	}
 
-	return lit, nil
+	return string(s.textBuf), nil
 }
@@ -2,15 +2,29 @@ package scanner
 
 // ident = letter { letter | decimalDigit | "_" }
 func (s *Scanner) scanIdent() string {
-	ident := string(s.read())
+	s.textBuf = appendRune(s.textBuf[:0], s.read())
 
 	for {
 		next := s.peek()
 		switch {
 		case isLetter(next), isDecimalDigit(next), next == '_':
-			ident += string(s.read())
+			s.textBuf = appendRune(s.textBuf, s.read())
 		default:
-			return ident
+			return s.text(s.textBuf)
 		}
 	}
 }
+
+// IsIdent reports whether s is an ident, which may begin with "_" as the scanner accepts.
+func IsIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if isLetter(r) || r == '_' || (0 < i && isDecimalDigit(r)) {
+			continue
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,46 @@
+package scanner
+
+import "unicode/utf8"
+
+// SetInterning enables or disables the interning of the scanned texts, so that the identical texts share the storage.
+func (s *Scanner) SetInterning(interning bool) {
+	if !interning {
+		s.interned = nil
+		return
+	}
+	if s.interned == nil {
+		s.interned = make(map[string]string)
+	}
+}
+
+// Intern returns the interned string equal to str when the interning is enabled. Otherwise, it returns str as is.
+func (s *Scanner) Intern(str string) string {
+	if s.interned == nil {
+		return str
+	}
+	if interned, ok := s.interned[str]; ok {
+		return interned
+	}
+	s.interned[str] = str
+	return str
+}
+
+// text returns buf as a string, which is interned when the interning is enabled.
+// Looking up the table by string(buf) doesn't allocate, so only the first occurrence of each text allocates.
+func (s *Scanner) text(buf []byte) string {
+	if s.interned == nil {
+		return string(buf)
+	}
+	if interned, ok := s.interned[string(buf)]; ok {
+		return interned
+	}
+	str := string(buf)
+	s.interned[str] = str
+	return str
+}
+
+func appendRune(buf []byte, r rune) []byte {
+	var b [utf8.UTFMax]byte
+	n := utf8.EncodeRune(b[:], r)
+	return append(buf, b[:n]...)
+}
@@ -2,8 +2,11 @@ package scanner
 
 import (
 	"bufio"
+	"bytes"
 	"io"
+	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 var eof = rune(0)
@@ -13,10 +16,21 @@ type Scanner struct {
 	r              *bufio.Reader
 	lastReadBuffer []rune
 	lastScanRaw    []rune
+	// source is the text read from r since the released part, which begins at the start of a line.
+	// It's kept to report the line of an error, to rewind and to slice the raw text.
+	source []byte
+	// sourceOffset and sourceLine are the offset and the line where source begins.
+	sourceOffset int
+	sourceLine   int
 
 	// pos is a current source position.
 	pos *Position
 
+	// textBuf is reused to build the text of a token.
+	textBuf []byte
+	// interned is the table of the interned texts. It's nil when the interning is disabled.
+	interned map[string]string
+
 	// The Mode field controls which tokens are recognized.
 	Mode Mode
 }
@@ -34,8 +48,9 @@ func WithFilename(filename string) Option {
 // NewScanner returns a new instance of Scanner.
 func NewScanner(r io.Reader, opts ...Option) *Scanner {
 	s := &Scanner{
-		r:   bufio.NewReader(r),
-		pos: NewPosition(),
+		r:          bufio.NewReader(r),
+		pos:        NewPosition(),
+		sourceLine: 1,
 	}
 	for _, opt := range opts {
 		opt(s)
@@ -62,9 +77,109 @@ func (s *Scanner) read() (r rune) {
 	if err != nil {
 		return eof
 	}
+	s.appendSource(ch)
 	return ch
 }
 
+// appendSource appends the rune read from r to the source.
+func (s *Scanner) appendSource(ch rune) {
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], ch)
+	s.source = append(s.source, buf[:n]...)
+}
+
+// readAhead reads a rune from r into the source without consuming it.
+// It returns false at the end of r.
+func (s *Scanner) readAhead() (rune, bool) {
+	ch, _, err := s.r.ReadRune()
+	if err != nil {
+		return eof, false
+	}
+	s.appendSource(ch)
+	// The read buffer pops from the end, so the rune is placed at the beginning to be read after the buffered ones.
+	s.lastReadBuffer = append([]rune{ch}, s.lastReadBuffer...)
+	return ch, true
+}
+
+// Source returns the text read so far, which is the whole input once the scanning reaches the end
+// unless a part of it has been released.
+func (s *Scanner) Source() string {
+	return string(s.source)
+}
+
+// SourceText returns the text between the offsets, and whether it's still kept.
+func (s *Scanner) SourceText(start, end int) (string, bool) {
+	start -= s.sourceOffset
+	end -= s.sourceOffset
+	if start < 0 || end < start || len(s.source) < end {
+		return "", false
+	}
+	return string(s.source[start:end]), true
+}
+
+// Release drops the text before the line containing the offset. Source, LineText and Rewind no longer
+// cover the released text, so that the text kept during the scanning is bounded by what remains to be used.
+// The text put back to the read buffer is kept even if it precedes the offset.
+func (s *Scanner) Release(offset int) {
+	if s.pos.Offset < offset {
+		offset = s.pos.Offset
+	}
+	end := offset - s.sourceOffset
+	if end <= 0 || len(s.source) < end {
+		return
+	}
+	lineStart := bytes.LastIndexByte(s.source[:end], '\n') + 1
+	if lineStart == 0 {
+		return
+	}
+	s.sourceLine += bytes.Count(s.source[:lineStart], []byte("\n"))
+	s.sourceOffset += lineStart
+	s.source = append(s.source[:0], s.source[lineStart:]...)
+}
+
+// LineText returns the text of the line, starting at 1, without the line break.
+// The rest of the line is read ahead without being consumed when the scanner is in the middle of it.
+// It returns an empty string when the input has no such line or the line has been released.
+func (s *Scanner) LineText(line int) string {
+	if line < s.sourceLine {
+		return ""
+	}
+
+	start := 0
+	for l := s.sourceLine; ; {
+		end := bytes.IndexByte(s.source[start:], '\n')
+		if end < 0 {
+			if s.readAheadLine() {
+				continue
+			}
+			if l < line {
+				return ""
+			}
+			return strings.TrimSuffix(string(s.source[start:]), "\r")
+		}
+		if l == line {
+			return strings.TrimSuffix(string(s.source[start:start+end]), "\r")
+		}
+		start += end + 1
+		l++
+	}
+}
+
+// readAheadLine reads ahead through the next line break. It returns false when r has nothing left.
+func (s *Scanner) readAheadLine() bool {
+	read := false
+	for {
+		ch, ok := s.readAhead()
+		if !ok {
+			return read
+		}
+		read = true
+		if ch == '\n' {
+			return true
+		}
+	}
+}
+
 func (s *Scanner) unread(ch rune) {
 	s.lastReadBuffer = append(s.lastReadBuffer, ch)
 
@@ -80,13 +195,34 @@ func (s *Scanner) peek() rune {
 	return ch
 }
 
+// isDigitAfterDot reports whether a decimal digit follows the next dot, which begins a floatLit like ".5".
+// Otherwise, the dot is the one of a fullIdent like "foo.bar".
+func (s *Scanner) isDigitAfterDot() bool {
+	dot := s.read()
+	next := s.peek()
+	s.lastScanRaw = s.lastScanRaw[0 : len(s.lastScanRaw)-1]
+	s.unread(dot)
+	return isDecimalDigit(next)
+}
+
 // UnScan put the last scanned text back to the read buffer.
+// The runes are put back from the last one, so that the first one is read first.
 func (s *Scanner) UnScan() {
-	var reversedRunes []rune
-	for _, ch := range s.lastScanRaw {
-		reversedRunes = append([]rune{ch}, reversedRunes...)
+	for i := len(s.lastScanRaw) - 1; 0 <= i; i-- {
+		s.unread(s.lastScanRaw[i])
+	}
+}
+
+// Rewind puts the scanned text back to the read buffer until the position returns to the offset.
+func (s *Scanner) Rewind(offset int) {
+	buffered := 0
+	for _, ch := range s.lastReadBuffer {
+		buffered += utf8.RuneLen(ch)
 	}
-	for _, ch := range reversedRunes {
+	consumed := s.source[:len(s.source)-buffered]
+	for 0 < len(consumed) && offset < s.pos.Offset {
+		ch, size := utf8.DecodeLastRune(consumed)
+		consumed = consumed[:len(consumed)-size]
 		s.unread(ch)
 	}
 }
@@ -147,13 +283,14 @@ func (s *Scanner) scan() (Token, string, Position, error) {
 			return TILLEGAL, "", startPos, err
 		}
 		return TSTRLIT, lit, startPos, nil
-	case (isDecimalDigit(ch) || ch == '.') && s.Mode&ScanNumberLit != 0:
+	case (isDecimalDigit(ch) || (ch == '.' && s.isDigitAfterDot())) && s.Mode&ScanNumberLit != 0:
 		tok, lit, err := s.scanNumberLit()
 		if err != nil {
 			return TILLEGAL, "", startPos, err
 		}
 		return tok, lit, startPos, nil
 	default:
-		return asMiscToken(ch), string(s.read()), startPos, nil
+		s.textBuf = appendRune(s.textBuf[:0], s.read())
+		return asMiscToken(ch), s.text(s.textBuf), startPos, nil
 	}
 }
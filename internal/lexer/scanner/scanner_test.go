@@ -634,6 +634,108 @@ fugafuga
 				},
 			},
 		},
+		{
+			name:  "scan comments with CRLF line breaks",
+			input: "// a\r\nb\r\n/* c\r\n*/\r\nd",
+			mode:  scanner.ScanComment,
+			wants: []want{
+				{
+					token: scanner.TCOMMENT,
+					text:  "// a",
+					pos: scanner.Position{
+						Position: meta.Position{
+							Offset: 0,
+							Line:   1,
+							Column: 1,
+						},
+					},
+				},
+				{
+					token: scanner.TIDENT,
+					text:  "b",
+					pos: scanner.Position{
+						Position: meta.Position{
+							Offset: 6,
+							Line:   2,
+							Column: 1,
+						},
+					},
+				},
+				{
+					token: scanner.TCOMMENT,
+					text:  "/* c\r\n*/",
+					pos: scanner.Position{
+						Position: meta.Position{
+							Offset: 9,
+							Line:   3,
+							Column: 1,
+						},
+					},
+				},
+				{
+					token: scanner.TIDENT,
+					text:  "d",
+					pos: scanner.Position{
+						Position: meta.Position{
+							Offset: 19,
+							Line:   5,
+							Column: 1,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "scan the dot of a fullIdent rather than a floatLit",
+			input: "a.b .5",
+			mode:  scanner.ScanNumberLit,
+			wants: []want{
+				{
+					token: scanner.TIDENT,
+					text:  "a",
+					pos: scanner.Position{
+						Position: meta.Position{
+							Offset: 0,
+							Line:   1,
+							Column: 1,
+						},
+					},
+				},
+				{
+					token: scanner.TDOT,
+					text:  ".",
+					pos: scanner.Position{
+						Position: meta.Position{
+							Offset: 1,
+							Line:   1,
+							Column: 2,
+						},
+					},
+				},
+				{
+					token: scanner.TIDENT,
+					text:  "b",
+					pos: scanner.Position{
+						Position: meta.Position{
+							Offset: 2,
+							Line:   1,
+							Column: 3,
+						},
+					},
+				},
+				{
+					token: scanner.TFLOATLIT,
+					text:  ".5",
+					pos: scanner.Position{
+						Position: meta.Position{
+							Offset: 4,
+							Line:   1,
+							Column: 5,
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -735,3 +837,102 @@ func TestScanner_UnScan(t *testing.T) {
 		})
 	}
 }
+
+func TestScanner_LineText(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		scanCount int
+		// releaseOffset is the offset released before reading the line, if any.
+		releaseOffset int
+		line          int
+		wantText      string
+		wantRest      []string
+	}{
+		{
+			name:      "reading the rest of the current line ahead",
+			input:     "message Foo {\r\n  int32 a = 1;\n}",
+			scanCount: 5,
+			line:      2,
+			wantText:  "  int32 a = 1;",
+			wantRest:  []string{"=", "1", ";", "}"},
+		},
+		{
+			name:      "reading a line which was already scanned",
+			input:     "message Foo {\n  int32 a = 1;\n}",
+			scanCount: 4,
+			line:      1,
+			wantText:  "message Foo {",
+			wantRest:  []string{"a", "=", "1", ";", "}"},
+		},
+		{
+			name:      "reading a line which was not scanned",
+			input:     "message Foo {\n  int32 a = 1;\n}",
+			scanCount: 1,
+			line:      3,
+			wantText:  "}",
+			wantRest:  []string{"Foo", "{", "int32", "a", "=", "1", ";", "}"},
+		},
+		{
+			name:      "reading a line beyond the input",
+			input:     "message Foo {}",
+			scanCount: 1,
+			line:      2,
+			wantRest:  []string{"Foo", "{", "}"},
+		},
+		{
+			name:          "reading a line with multi-byte characters after releasing the preceding lines",
+			input:         "message Foo {\n  string a = 1 [(x) = \"é\"];\n}",
+			scanCount:     10,
+			releaseOffset: 20,
+			line:          2,
+			wantText:      `  string a = 1 [(x) = "é"];`,
+			wantRest:      []string{")", "=", `"`, "é", `"`, "]", ";", "}"},
+		},
+		{
+			name:          "reading a line which was released",
+			input:         "message Foo {\n  int32 a = 1;\n}",
+			scanCount:     5,
+			releaseOffset: 20,
+			line:          1,
+			wantRest:      []string{"=", "1", ";", "}"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			s := scanner.NewScanner(strings.NewReader(test.input))
+			for i := 0; i < test.scanCount; i++ {
+				_, _, _, err := s.Scan()
+				if err != nil {
+					t.Fatalf("got err %v, but want nil", err)
+				}
+			}
+
+			if 0 < test.releaseOffset {
+				s.Release(test.releaseOffset)
+			}
+
+			got := s.LineText(test.line)
+			if got != test.wantText {
+				t.Errorf("got %q, but want %q", got, test.wantText)
+			}
+
+			var rest []string
+			for {
+				token, text, _, err := s.Scan()
+				if err != nil {
+					t.Fatalf("got err %v, but want nil", err)
+				}
+				if token == scanner.TEOF {
+					break
+				}
+				rest = append(rest, text)
+			}
+			if strings.Join(rest, " ") != strings.Join(test.wantRest, " ") {
+				t.Errorf("got %v, but want %v", rest, test.wantRest)
+			}
+		})
+	}
+}
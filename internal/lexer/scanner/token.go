@@ -39,6 +39,7 @@ const (
 
 	// Keywords
 	TSYNTAX
+	TEDITION
 	TSERVICE
 	TRPC
 	TRETURNS
@@ -88,6 +89,7 @@ func asMiscToken(ch rune) Token {
 func asKeywordToken(st string) Token {
 	m := map[string]Token{
 		"syntax":     TSYNTAX,
+		"edition":    TEDITION,
 		"service":    TSERVICE,
 		"rpc":        TRPC,
 		"returns":    TRETURNS,
@@ -0,0 +1,162 @@
+// Package lexer provides a stream of the lexical tokens of a protocol buffer file
+// for tools which don't need the AST, like syntax highlighters.
+package lexer
+
+import (
+	"io"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer/scanner"
+	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
+)
+
+// Kind is a kind of the token.
+type Kind uint
+
+// The kinds of the token.
+const (
+	// KindIllegal is a character which doesn't start any token.
+	KindIllegal Kind = iota
+	// KindEOF is the end of the input.
+	KindEOF
+	// KindIdent is an identifier other than the keywords.
+	KindIdent
+	// KindKeyword is a keyword like "message" and "option".
+	KindKeyword
+	// KindIntLit is an integer literal like "1" and "0x1F".
+	KindIntLit
+	// KindFloatLit is a float literal like "1.5e3", "inf" and "nan".
+	KindFloatLit
+	// KindBoolLit is either "true" or "false".
+	KindBoolLit
+	// KindStrLit is a string literal including the quotes.
+	KindStrLit
+	// KindComment is a line or block comment including the delimiters.
+	KindComment
+	// KindSymbol is a punctuation like ";", "{" and "-".
+	KindSymbol
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case KindEOF:
+		return "EOF"
+	case KindIdent:
+		return "Ident"
+	case KindKeyword:
+		return "Keyword"
+	case KindIntLit:
+		return "IntLit"
+	case KindFloatLit:
+		return "FloatLit"
+	case KindBoolLit:
+		return "BoolLit"
+	case KindStrLit:
+		return "StrLit"
+	case KindComment:
+		return "Comment"
+	case KindSymbol:
+		return "Symbol"
+	default:
+		return "Illegal"
+	}
+}
+
+// Token is a lexical token.
+type Token struct {
+	// Kind is the kind of the token.
+	Kind Kind
+	// Text is the text of the token as written in the source.
+	Text string
+	// Pos is the position where the token starts.
+	Pos meta.Position
+}
+
+// Lexer scans the tokens one by one. The whitespaces between them are skipped.
+type Lexer struct {
+	scanner     *scanner.Scanner
+	scannerOpts []scanner.Option
+
+	peeked  *Token
+	peekErr error
+}
+
+// Option is an option for lexer.NewLexer.
+type Option func(*Lexer)
+
+// WithFilename is an option to set the filename to the positions of the tokens.
+func WithFilename(filename string) Option {
+	return func(l *Lexer) {
+		l.scannerOpts = append(l.scannerOpts, scanner.WithFilename(filename))
+	}
+}
+
+// NewLexer creates a new lexer.
+func NewLexer(input io.Reader, opts ...Option) *Lexer {
+	lex := new(Lexer)
+	for _, opt := range opts {
+		opt(lex)
+	}
+
+	lex.scanner = scanner.NewScanner(input, lex.scannerOpts...)
+	lex.scanner.Mode = scanner.ScanIdent | scanner.ScanLit | scanner.ScanKeyword | scanner.ScanComment
+	return lex
+}
+
+// Next scans the next token. It returns the token of KindEOF at the end of the input, repeatedly.
+// It returns an error when the input is malformed, like an unterminated string literal.
+func (lex *Lexer) Next() (Token, error) {
+	if lex.peeked != nil {
+		token, err := *lex.peeked, lex.peekErr
+		lex.peeked, lex.peekErr = nil, nil
+		return token, err
+	}
+
+	t, text, pos, err := lex.scanner.Scan()
+	token := Token{
+		Kind: asKind(t, text),
+		Text: text,
+		Pos:  pos.Position,
+	}
+	if err != nil {
+		token.Kind = KindIllegal
+	}
+	return token, err
+}
+
+// Peek returns the next token without consuming it. The following Next returns the same token.
+func (lex *Lexer) Peek() (Token, error) {
+	if lex.peeked == nil {
+		token, err := lex.Next()
+		lex.peeked, lex.peekErr = &token, err
+	}
+	return *lex.peeked, lex.peekErr
+}
+
+func asKind(t scanner.Token, text string) Kind {
+	switch {
+	case t == scanner.TEOF:
+		return KindEOF
+	case t == scanner.TIDENT:
+		return KindIdent
+	case t == scanner.TINTLIT:
+		return KindIntLit
+	case t == scanner.TFLOATLIT:
+		return KindFloatLit
+	case t == scanner.TBOOLLIT:
+		return KindBoolLit
+	case t == scanner.TSTRLIT:
+		return KindStrLit
+	case t == scanner.TCOMMENT:
+		return KindComment
+	case scanner.TSEMICOLON <= t && t <= scanner.TDOT:
+		return KindSymbol
+	case scanner.TSYNTAX <= t:
+		return KindKeyword
+	case text == "-" || text == "+":
+		// The sign of a constant isn't a token of the scanner.
+		return KindSymbol
+	default:
+		return KindIllegal
+	}
+}
@@ -0,0 +1,111 @@
+package lexer_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
+)
+
+func TestLexer_Next(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantTokens []lexer.Token
+	}{
+		{
+			name:  "scanning a field with an option",
+			input: `repeated google.protobuf.Timestamp ts = 1 [deprecated=true]; // c`,
+			wantTokens: []lexer.Token{
+				{Kind: lexer.KindKeyword, Text: "repeated", Pos: meta.Position{Filename: "a.proto", Offset: 0, Line: 1, Column: 1}},
+				{Kind: lexer.KindIdent, Text: "google", Pos: meta.Position{Filename: "a.proto", Offset: 9, Line: 1, Column: 10}},
+				{Kind: lexer.KindSymbol, Text: ".", Pos: meta.Position{Filename: "a.proto", Offset: 15, Line: 1, Column: 16}},
+				{Kind: lexer.KindIdent, Text: "protobuf", Pos: meta.Position{Filename: "a.proto", Offset: 16, Line: 1, Column: 17}},
+				{Kind: lexer.KindSymbol, Text: ".", Pos: meta.Position{Filename: "a.proto", Offset: 24, Line: 1, Column: 25}},
+				{Kind: lexer.KindIdent, Text: "Timestamp", Pos: meta.Position{Filename: "a.proto", Offset: 25, Line: 1, Column: 26}},
+				{Kind: lexer.KindIdent, Text: "ts", Pos: meta.Position{Filename: "a.proto", Offset: 35, Line: 1, Column: 36}},
+				{Kind: lexer.KindSymbol, Text: "=", Pos: meta.Position{Filename: "a.proto", Offset: 38, Line: 1, Column: 39}},
+				{Kind: lexer.KindIntLit, Text: "1", Pos: meta.Position{Filename: "a.proto", Offset: 40, Line: 1, Column: 41}},
+				{Kind: lexer.KindSymbol, Text: "[", Pos: meta.Position{Filename: "a.proto", Offset: 42, Line: 1, Column: 43}},
+				{Kind: lexer.KindIdent, Text: "deprecated", Pos: meta.Position{Filename: "a.proto", Offset: 43, Line: 1, Column: 44}},
+				{Kind: lexer.KindSymbol, Text: "=", Pos: meta.Position{Filename: "a.proto", Offset: 53, Line: 1, Column: 54}},
+				{Kind: lexer.KindBoolLit, Text: "true", Pos: meta.Position{Filename: "a.proto", Offset: 54, Line: 1, Column: 55}},
+				{Kind: lexer.KindSymbol, Text: "]", Pos: meta.Position{Filename: "a.proto", Offset: 58, Line: 1, Column: 59}},
+				{Kind: lexer.KindSymbol, Text: ";", Pos: meta.Position{Filename: "a.proto", Offset: 59, Line: 1, Column: 60}},
+				{Kind: lexer.KindComment, Text: "// c", Pos: meta.Position{Filename: "a.proto", Offset: 61, Line: 1, Column: 62}},
+				{Kind: lexer.KindEOF, Text: "", Pos: meta.Position{Filename: "a.proto", Offset: 65, Line: 1, Column: 66}},
+			},
+		},
+		{
+			name:  "scanning the literals",
+			input: "-.5 1.5e3 0x1F inf 'a'\n\"b\"",
+			wantTokens: []lexer.Token{
+				{Kind: lexer.KindSymbol, Text: "-", Pos: meta.Position{Filename: "a.proto", Offset: 0, Line: 1, Column: 1}},
+				{Kind: lexer.KindFloatLit, Text: ".5", Pos: meta.Position{Filename: "a.proto", Offset: 1, Line: 1, Column: 2}},
+				{Kind: lexer.KindFloatLit, Text: "1.5e3", Pos: meta.Position{Filename: "a.proto", Offset: 4, Line: 1, Column: 5}},
+				{Kind: lexer.KindIntLit, Text: "0x1F", Pos: meta.Position{Filename: "a.proto", Offset: 10, Line: 1, Column: 11}},
+				{Kind: lexer.KindFloatLit, Text: "inf", Pos: meta.Position{Filename: "a.proto", Offset: 15, Line: 1, Column: 16}},
+				{Kind: lexer.KindStrLit, Text: "'a'", Pos: meta.Position{Filename: "a.proto", Offset: 19, Line: 1, Column: 20}},
+				{Kind: lexer.KindStrLit, Text: `"b"`, Pos: meta.Position{Filename: "a.proto", Offset: 23, Line: 2, Column: 1}},
+				{Kind: lexer.KindEOF, Text: "", Pos: meta.Position{Filename: "a.proto", Offset: 26, Line: 2, Column: 4}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			lex := lexer.NewLexer(strings.NewReader(test.input), lexer.WithFilename("a.proto"))
+			var got []lexer.Token
+			for {
+				token, err := lex.Next()
+				if err != nil {
+					t.Fatalf("got err %v", err)
+				}
+				got = append(got, token)
+				if token.Kind == lexer.KindEOF {
+					break
+				}
+			}
+			if !reflect.DeepEqual(got, test.wantTokens) {
+				t.Errorf("got %v, but want %v", got, test.wantTokens)
+			}
+		})
+	}
+}
+
+func TestLexer_Peek(t *testing.T) {
+	lex := lexer.NewLexer(strings.NewReader("message Foo"))
+
+	peeked, err := lex.Peek()
+	if err != nil {
+		t.Fatalf("got err %v", err)
+	}
+	if again, _ := lex.Peek(); again != peeked {
+		t.Errorf("got %v, but want %v", again, peeked)
+	}
+	next, _ := lex.Next()
+	if next != peeked {
+		t.Errorf("got %v, but want %v", next, peeked)
+	}
+	if next.Kind != lexer.KindKeyword || next.Text != "message" {
+		t.Errorf("got %v, but want the keyword message", next)
+	}
+	if ident, _ := lex.Next(); ident.Kind != lexer.KindIdent || ident.Text != "Foo" {
+		t.Errorf("got %v, but want the ident Foo", ident)
+	}
+}
+
+func TestLexer_Next_error(t *testing.T) {
+	lex := lexer.NewLexer(strings.NewReader(`"unterminated`))
+
+	token, err := lex.Next()
+	if err == nil {
+		t.Fatalf("got err nil, but want an error")
+	}
+	if token.Kind != lexer.KindIllegal {
+		t.Errorf("got %v, but want %v", token.Kind, lexer.KindIllegal)
+	}
+}
@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer/scanner"
+)
+
+// OptionConstant is the structured value of an option constant.
+// Either Scalar or Fields is set.
+type OptionConstant struct {
+	// Scalar keeps the original spelling of a non-aggregate constant, so a string includes the quotes.
+	Scalar string
+	// Fields maps each field name of an aggregate like `{ post: "/v1" body: "*" }` to its values.
+	// A name has multiple values when it is repeated or given a list value like `ids: [1, 2, 3]`,
+	// and they are kept in order. An empty list leaves the name with no values.
+	// The text of the whole constant is still available as Option.Constant.
+	Fields map[string][]*OptionConstant
+	// Names lists the field names of an aggregate in the order of their first appearance.
+	Names []string
+}
+
+// IsAggregate reports whether the constant is an aggregate.
+func (c *OptionConstant) IsAggregate() bool {
+	return c.Fields != nil
+}
+
+// StructuredConstant interprets the Constant into an OptionConstant.
+func (o *Option) StructuredConstant() (*OptionConstant, error) {
+	return ParseOptionConstant(o.Constant)
+}
+
+// ParseOptionConstant interprets the raw text of an option constant, such as Option.Constant and FieldOption.Constant.
+func ParseOptionConstant(constant string) (*OptionConstant, error) {
+	p := NewParser(lexer.NewLexer(strings.NewReader(constant)), WithPermissive(true))
+	if p.lex.Peek() != scanner.TLEFTCURLY {
+		return &OptionConstant{Scalar: constant}, nil
+	}
+
+	return p.parseAggregateFields()
+}
+
+// aggregateFields = "{" { ident [ ":" ] ( aggregateFields | "[" aggregateValues "]" | constant ) [ "," | ";" ] } "}"
+func (p *Parser) parseAggregateFields() (*OptionConstant, error) {
+	p.lex.Next()
+	if p.lex.Token != scanner.TLEFTCURLY {
+		return nil, p.unexpected("{")
+	}
+
+	aggregate := &OptionConstant{
+		Fields: make(map[string][]*OptionConstant),
+	}
+	for {
+		p.lex.Next()
+		switch p.lex.Token {
+		case scanner.TRIGHTCURLY:
+			return aggregate, nil
+		case scanner.TCOMMA, scanner.TSEMICOLON:
+			continue
+		case scanner.TIDENT:
+		default:
+			return nil, p.unexpected("ident or }")
+		}
+		name := p.lex.Text
+
+		p.lex.ConsumeToken(scanner.TCOLON)
+
+		values, err := p.parseAggregateValues()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := aggregate.Fields[name]; !ok {
+			aggregate.Names = append(aggregate.Names, name)
+		}
+		aggregate.Fields[name] = append(aggregate.Fields[name], values...)
+	}
+}
+
+// aggregateValues = aggregateFields | "[" [ aggregateValue { "," aggregateValue } ] "]" | constant
+// A list value is flattened into multiple values.
+func (p *Parser) parseAggregateValues() ([]*OptionConstant, error) {
+	switch p.lex.Peek() {
+	case scanner.TLEFTCURLY:
+		aggregate, err := p.parseAggregateFields()
+		if err != nil {
+			return nil, err
+		}
+		return []*OptionConstant{aggregate}, nil
+	case scanner.TLEFTSQUARE:
+		p.lex.Next()
+
+		var values []*OptionConstant
+		for {
+			p.lex.Next()
+			switch p.lex.Token {
+			case scanner.TRIGHTSQUARE:
+				return values, nil
+			case scanner.TCOMMA:
+				continue
+			}
+			p.lex.UnNext()
+
+			value, err := p.parseAggregateValues()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value...)
+		}
+	default:
+		constant, _, err := p.lex.ReadConstant()
+		if err != nil {
+			return nil, err
+		}
+		return []*OptionConstant{{Scalar: constant}}, nil
+	}
+}
+
+// unquote removes the surrounding quotes of a strLit, if any.
+func unquote(s string) string {
+	if len(s) < 2 {
+		return s
+	}
+	if (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,158 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestParseOptionConstant(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputConstant string
+		wantConstant  *parser.OptionConstant
+		wantErr       bool
+	}{
+		{
+			name:          "parsing a scalar",
+			inputConstant: `"com.example.foo"`,
+			wantConstant: &parser.OptionConstant{
+				Scalar: `"com.example.foo"`,
+			},
+		},
+		{
+			name:          "parsing an empty aggregate",
+			inputConstant: `{}`,
+			wantConstant: &parser.OptionConstant{
+				Fields: map[string][]*parser.OptionConstant{},
+			},
+		},
+		{
+			name:          "parsing an aggregate",
+			inputConstant: `{length_gt:0, msg:"x"}`,
+			wantConstant: &parser.OptionConstant{
+				Fields: map[string][]*parser.OptionConstant{
+					"length_gt": {
+						{Scalar: "0"},
+					},
+					"msg": {
+						{Scalar: `"x"`},
+					},
+				},
+				Names: []string{"length_gt", "msg"},
+			},
+		},
+		{
+			name: "parsing a nested aggregate with a list and a repeated name",
+			inputConstant: `{get:"/v1/a"
+additional_bindings {post:"/v1/b"}
+additional_bindings [{post:"/v1/c"}, {post:"/v1/d"}]}`,
+			wantConstant: &parser.OptionConstant{
+				Fields: map[string][]*parser.OptionConstant{
+					"get": {
+						{Scalar: `"/v1/a"`},
+					},
+					"additional_bindings": {
+						{
+							Fields: map[string][]*parser.OptionConstant{
+								"post": {{Scalar: `"/v1/b"`}},
+							},
+							Names: []string{"post"},
+						},
+						{
+							Fields: map[string][]*parser.OptionConstant{
+								"post": {{Scalar: `"/v1/c"`}},
+							},
+							Names: []string{"post"},
+						},
+						{
+							Fields: map[string][]*parser.OptionConstant{
+								"post": {{Scalar: `"/v1/d"`}},
+							},
+							Names: []string{"post"},
+						},
+					},
+				},
+				Names: []string{"get", "additional_bindings"},
+			},
+		},
+		{
+			name:          "parsing an invalid aggregate",
+			inputConstant: `{length_gt:0`,
+			wantErr:       true,
+		},
+		{
+			name:          "parsing lists of scalars",
+			inputConstant: `{ ids: [1, 2, 3], names: ["a", 'b'], empty: [] }`,
+			wantConstant: &parser.OptionConstant{
+				Fields: map[string][]*parser.OptionConstant{
+					"ids": {
+						{Scalar: "1"},
+						{Scalar: "2"},
+						{Scalar: "3"},
+					},
+					"names": {
+						{Scalar: `"a"`},
+						{Scalar: `'b'`},
+					},
+					"empty": nil,
+				},
+				Names: []string{"ids", "names", "empty"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parser.ParseOptionConstant(test.inputConstant)
+			switch {
+			case test.wantErr:
+				if err == nil {
+					t.Errorf("got err nil, but want err")
+				}
+				return
+			case !test.wantErr && err != nil:
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			if !reflect.DeepEqual(got, test.wantConstant) {
+				t.Errorf("got %v, but want %v", got, test.wantConstant)
+			}
+			if got.IsAggregate() != (test.wantConstant.Fields != nil) {
+				t.Errorf("got %v, but want %v", got.IsAggregate(), test.wantConstant.Fields != nil)
+			}
+		})
+	}
+}
+
+func TestOption_StructuredConstant(t *testing.T) {
+	input := `option (x) = { ids: [1, 2, 3] };`
+	wantConstant := `{ids:[1,2,3]}`
+	wantIDs := []string{"1", "2", "3"}
+
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)), parser.WithPermissive(true))
+	option, err := p.ParseOption()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if option.Constant != wantConstant {
+		t.Errorf("got %q, but want %q", option.Constant, wantConstant)
+	}
+
+	constant, err := option.StructuredConstant()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotIDs []string
+	for _, id := range constant.Fields["ids"] {
+		gotIDs = append(gotIDs, id.Scalar)
+	}
+	if !reflect.DeepEqual(gotIDs, wantIDs) {
+		t.Errorf("got %v, but want %v", gotIDs, wantIDs)
+	}
+}
@@ -0,0 +1,153 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
+)
+
+// boolOptions are the options of the bool type defined by descriptor.proto.
+// Only their numeric constants "1" and "0" are taken as the bool spellings, since a custom option may be an integer.
+var boolOptions = map[string]struct{}{
+	"allow_alias":                            {},
+	"cc_enable_arenas":                       {},
+	"cc_generic_services":                    {},
+	"debug_redact":                           {},
+	"deprecated":                             {},
+	"deprecated_legacy_json_field_conflicts": {},
+	"java_generic_services":                  {},
+	"java_multiple_files":                    {},
+	"java_string_check_utf8":                 {},
+	"lazy":                                   {},
+	"map_entry":                              {},
+	"message_set_wire_format":                {},
+	"no_standard_descriptor_accessor":        {},
+	"packed":                                 {},
+	"php_generic_services":                   {},
+	"py_generic_services":                    {},
+	"unverified_lazy":                        {},
+	"weak":                                   {},
+}
+
+// boolSpellingWarnings returns the warnings about the bool constants which LenientBoolValue accepts
+// and BoolValue doesn't, that is "True" and "False" of any option and "1" and "0" of the bool options.
+func boolSpellingWarnings(proto *Proto) []*MigrationWarning {
+	c := &boolSpellingCollector{}
+	proto.Accept(c)
+	return c.warnings
+}
+
+type boolSpellingCollector struct {
+	warnings []*MigrationWarning
+}
+
+func (c *boolSpellingCollector) check(name string, constant string, pos meta.Position) {
+	_, isBoolOption := boolOptions[name]
+	value, ok := (&Option{Constant: constant}).LenientBoolValue(isBoolOption)
+	if !ok {
+		return
+	}
+	if _, canonical := boolConstant(constant); canonical {
+		return
+	}
+	c.warnings = append(c.warnings, &MigrationWarning{
+		Pos:        pos,
+		Message:    fmt.Sprintf("option %q spells the bool as %q", name, constant),
+		Suggestion: fmt.Sprintf("use %v", value),
+	})
+}
+
+func (c *boolSpellingCollector) checkFieldOptions(options []*FieldOption) {
+	for _, option := range options {
+		c.check(option.OptionName, option.Constant, option.Meta.Pos)
+	}
+}
+
+func (c *boolSpellingCollector) VisitComment(*Comment) {}
+
+func (c *boolSpellingCollector) VisitEdition(*Edition) bool {
+	return false
+}
+
+func (c *boolSpellingCollector) VisitEmptyStatement(*EmptyStatement) bool {
+	return false
+}
+
+func (c *boolSpellingCollector) VisitEnum(*Enum) bool {
+	return true
+}
+
+func (c *boolSpellingCollector) VisitEnumField(e *EnumField) bool {
+	// An enum value option has no position of its own.
+	for _, option := range e.EnumValueOptions {
+		c.check(option.OptionName, option.Constant, e.Meta.Pos)
+	}
+	return false
+}
+
+func (c *boolSpellingCollector) VisitExtend(*Extend) bool {
+	return true
+}
+
+func (c *boolSpellingCollector) VisitExtensions(*Extensions) bool {
+	return false
+}
+
+func (c *boolSpellingCollector) VisitField(f *Field) bool {
+	c.checkFieldOptions(f.FieldOptions)
+	return false
+}
+
+func (c *boolSpellingCollector) VisitGroupField(*GroupField) bool {
+	return true
+}
+
+func (c *boolSpellingCollector) VisitImport(*Import) bool {
+	return false
+}
+
+func (c *boolSpellingCollector) VisitMapField(m *MapField) bool {
+	c.checkFieldOptions(m.FieldOptions)
+	return false
+}
+
+func (c *boolSpellingCollector) VisitMessage(*Message) bool {
+	return true
+}
+
+func (c *boolSpellingCollector) VisitOneof(*Oneof) bool {
+	return true
+}
+
+func (c *boolSpellingCollector) VisitOneofField(f *OneofField) bool {
+	c.checkFieldOptions(f.FieldOptions)
+	return false
+}
+
+func (c *boolSpellingCollector) VisitOption(o *Option) bool {
+	c.check(o.OptionName, o.Constant, o.Meta.Pos)
+	return false
+}
+
+func (c *boolSpellingCollector) VisitPackage(*Package) bool {
+	return false
+}
+
+func (c *boolSpellingCollector) VisitReserved(*Reserved) bool {
+	return false
+}
+
+func (c *boolSpellingCollector) VisitRPC(rpc *RPC) bool {
+	for _, option := range rpc.Options {
+		option.Accept(c)
+	}
+	return false
+}
+
+func (c *boolSpellingCollector) VisitService(*Service) bool {
+	return true
+}
+
+func (c *boolSpellingCollector) VisitSyntax(*Syntax) bool {
+	return false
+}
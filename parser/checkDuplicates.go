@@ -0,0 +1,46 @@
+package parser
+
+// CheckDuplicates reports the fields in the message, including its nested ones, which share a name or a number
+// with another field in the same message. Oneof fields share the name and number spaces of the enclosing message.
+// Each error is positioned at the later field and refers to the name and the position of the earlier one.
+func CheckDuplicates(msg *Message) []error {
+	return checkDuplicatesBody(msg.MessageBody)
+}
+
+func checkDuplicatesBody(body []Visitee) []error {
+	var errs []error
+
+	names := make(map[string]numberedField)
+	numbers := make(map[int]numberedField)
+	for _, field := range numberedFields(body) {
+		if first, ok := names[field.name]; ok {
+			errs = append(errs, newValidationError(
+				field.pos,
+				"field %q duplicates the name of field %q at %s",
+				field.name, first.name, first.pos,
+			))
+		} else {
+			names[field.name] = field
+		}
+
+		if first, ok := numbers[field.number]; ok {
+			errs = append(errs, newValidationError(
+				field.pos,
+				"field %q duplicates the number %d of field %q at %s",
+				field.name, field.number, first.name, first.pos,
+			))
+		} else {
+			numbers[field.number] = field
+		}
+	}
+
+	for _, element := range body {
+		switch e := element.(type) {
+		case *Message:
+			errs = append(errs, checkDuplicatesBody(e.MessageBody)...)
+		case *GroupField:
+			errs = append(errs, checkDuplicatesBody(e.MessageBody)...)
+		}
+	}
+	return errs
+}
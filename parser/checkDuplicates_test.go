@@ -0,0 +1,91 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestCheckDuplicates(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantErrs []string
+	}{
+		{
+			name: "checking a message without duplicates",
+			input: `message Foo {
+  int32 a = 1;
+  oneof b {
+    string c = 2;
+  }
+  map<string, int32> d = 3;
+  message Bar {
+    int32 a = 1;
+  }
+}`,
+		},
+		{
+			name: "checking fields which share a name",
+			input: `message Foo {
+  int32 a = 1;
+  map<string, int32> a = 2;
+  oneof b {
+    string a = 3;
+  }
+}`,
+			wantErrs: []string{
+				`<input>:3:3: field "a" duplicates the name of field "a" at <input>:2:3`,
+				`<input>:5:5: field "a" duplicates the name of field "a" at <input>:2:3`,
+			},
+		},
+		{
+			name: "checking fields which share a number inside and outside a oneof",
+			input: `message Foo {
+  int32 a = 1;
+  oneof b {
+    string c = 1;
+  }
+}`,
+			wantErrs: []string{
+				`<input>:4:5: field "c" duplicates the number 1 of field "a" at <input>:2:3`,
+			},
+		},
+		{
+			name: "checking a field which shares both a name and a number in a nested message",
+			input: `message Foo {
+  message Bar {
+    int32 a = 1;
+    string a = 1;
+  }
+}`,
+			wantErrs: []string{
+				`<input>:4:5: field "a" duplicates the name of field "a" at <input>:3:5`,
+				`<input>:4:5: field "a" duplicates the number 1 of field "a" at <input>:3:5`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			msg, err := p.ParseMessage()
+			if err != nil {
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			var got []string
+			for _, e := range parser.CheckDuplicates(msg) {
+				got = append(got, e.Error())
+			}
+			if !reflect.DeepEqual(got, test.wantErrs) {
+				t.Errorf("got %v, but want %v", got, test.wantErrs)
+			}
+		})
+	}
+}
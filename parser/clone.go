@@ -0,0 +1,319 @@
+package parser
+
+// Clone returns a deep copy of the proto, which can be modified without affecting the original.
+func (p *Proto) Clone() *Proto {
+	c := *p
+	if p.Syntax != nil {
+		c.Syntax = p.Syntax.Clone()
+	}
+	if p.Edition != nil {
+		c.Edition = p.Edition.Clone()
+	}
+	c.ProtoBody = cloneBody(p.ProtoBody)
+	if p.Meta != nil {
+		meta := *p.Meta
+		c.Meta = &meta
+	}
+	return &c
+}
+
+// Clone returns a deep copy of the comment. It returns nil for a nil comment, such as an absent InlineComment.
+func (c *Comment) Clone() *Comment {
+	if c == nil {
+		return nil
+	}
+	clone := *c
+	return &clone
+}
+
+// Clone returns a deep copy of the empty statement.
+func (e *EmptyStatement) Clone() *EmptyStatement {
+	c := *e
+	c.InlineComment = e.InlineComment.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the syntax.
+func (s *Syntax) Clone() *Syntax {
+	c := *s
+	c.Comments = cloneComments(s.Comments)
+	c.InlineComment = s.InlineComment.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the edition.
+func (e *Edition) Clone() *Edition {
+	c := *e
+	c.Comments = cloneComments(e.Comments)
+	c.InlineComment = e.InlineComment.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the import.
+func (i *Import) Clone() *Import {
+	c := *i
+	c.Comments = cloneComments(i.Comments)
+	c.InlineComment = i.InlineComment.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the package.
+func (p *Package) Clone() *Package {
+	c := *p
+	c.Comments = cloneComments(p.Comments)
+	c.InlineComment = p.InlineComment.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the option.
+func (o *Option) Clone() *Option {
+	c := *o
+	c.Comments = cloneComments(o.Comments)
+	c.InlineComment = o.InlineComment.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the message including its body.
+func (m *Message) Clone() *Message {
+	c := *m
+	c.MessageBody = cloneBody(m.MessageBody)
+	c.Comments = cloneComments(m.Comments)
+	c.InlineComment = m.InlineComment.Clone()
+	c.InlineCommentBehindLeftCurly = m.InlineCommentBehindLeftCurly.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the enum including its body.
+func (e *Enum) Clone() *Enum {
+	c := *e
+	c.EnumBody = cloneBody(e.EnumBody)
+	c.Comments = cloneComments(e.Comments)
+	c.InlineComment = e.InlineComment.Clone()
+	c.InlineCommentBehindLeftCurly = e.InlineCommentBehindLeftCurly.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the enum field.
+func (f *EnumField) Clone() *EnumField {
+	c := *f
+	c.EnumValueOptions = nil
+	for _, option := range f.EnumValueOptions {
+		optionClone := *option
+		c.EnumValueOptions = append(c.EnumValueOptions, &optionClone)
+	}
+	c.Comments = cloneComments(f.Comments)
+	c.InlineComment = f.InlineComment.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the service including its body.
+func (s *Service) Clone() *Service {
+	c := *s
+	c.ServiceBody = cloneBody(s.ServiceBody)
+	c.Comments = cloneComments(s.Comments)
+	c.InlineComment = s.InlineComment.Clone()
+	c.InlineCommentBehindLeftCurly = s.InlineCommentBehindLeftCurly.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the rpc including its options.
+func (r *RPC) Clone() *RPC {
+	c := *r
+	if r.RPCRequest != nil {
+		request := *r.RPCRequest
+		c.RPCRequest = &request
+	}
+	if r.RPCResponse != nil {
+		response := *r.RPCResponse
+		c.RPCResponse = &response
+	}
+	c.Options = cloneOptions(r.Options)
+	c.Comments = cloneComments(r.Comments)
+	c.InlineComment = r.InlineComment.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the extend including its body.
+func (e *Extend) Clone() *Extend {
+	c := *e
+	c.ExtendBody = cloneBody(e.ExtendBody)
+	c.Comments = cloneComments(e.Comments)
+	c.InlineComment = e.InlineComment.Clone()
+	c.InlineCommentBehindLeftCurly = e.InlineCommentBehindLeftCurly.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the field.
+func (f *Field) Clone() *Field {
+	c := *f
+	c.FieldOptions = cloneFieldOptions(f.FieldOptions)
+	c.Comments = cloneComments(f.Comments)
+	c.InlineComment = f.InlineComment.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the map field.
+func (m *MapField) Clone() *MapField {
+	c := *m
+	c.FieldOptions = cloneFieldOptions(m.FieldOptions)
+	c.Comments = cloneComments(m.Comments)
+	c.InlineComment = m.InlineComment.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the group field including its body.
+func (g *GroupField) Clone() *GroupField {
+	c := *g
+	c.MessageBody = cloneBody(g.MessageBody)
+	c.Comments = cloneComments(g.Comments)
+	c.InlineComment = g.InlineComment.Clone()
+	c.InlineCommentBehindLeftCurly = g.InlineCommentBehindLeftCurly.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the oneof including its fields and options.
+func (o *Oneof) Clone() *Oneof {
+	c := *o
+	c.OneofFields = nil
+	for _, field := range o.OneofFields {
+		c.OneofFields = append(c.OneofFields, field.Clone())
+	}
+	c.Options = cloneOptions(o.Options)
+	c.Comments = cloneComments(o.Comments)
+	c.InlineComment = o.InlineComment.Clone()
+	c.InlineCommentBehindLeftCurly = o.InlineCommentBehindLeftCurly.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the oneof field.
+func (f *OneofField) Clone() *OneofField {
+	c := *f
+	c.FieldOptions = cloneFieldOptions(f.FieldOptions)
+	c.Comments = cloneComments(f.Comments)
+	c.InlineComment = f.InlineComment.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the reserved.
+func (r *Reserved) Clone() *Reserved {
+	c := *r
+	c.Ranges = cloneRanges(r.Ranges)
+	c.FieldNames = append([]string(nil), r.FieldNames...)
+	c.Comments = cloneComments(r.Comments)
+	c.InlineComment = r.InlineComment.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of the extensions.
+func (e *Extensions) Clone() *Extensions {
+	c := *e
+	c.Ranges = cloneRanges(e.Ranges)
+	c.Comments = cloneComments(e.Comments)
+	c.InlineComment = e.InlineComment.Clone()
+	return &c
+}
+
+// cloneBody returns a deep copy of the body. An element of an unknown type is shared with the original.
+func cloneBody(body []Visitee) []Visitee {
+	if body == nil {
+		return nil
+	}
+	clone := make([]Visitee, 0, len(body))
+	for _, element := range body {
+		clone = append(clone, cloneElement(element))
+	}
+	return clone
+}
+
+func cloneElement(element Visitee) Visitee {
+	switch e := element.(type) {
+	case *Comment:
+		return e.Clone()
+	case *EmptyStatement:
+		return e.Clone()
+	case *Syntax:
+		return e.Clone()
+	case *Edition:
+		return e.Clone()
+	case *Import:
+		return e.Clone()
+	case *Package:
+		return e.Clone()
+	case *Option:
+		return e.Clone()
+	case *Message:
+		return e.Clone()
+	case *Enum:
+		return e.Clone()
+	case *EnumField:
+		return e.Clone()
+	case *Service:
+		return e.Clone()
+	case *RPC:
+		return e.Clone()
+	case *Extend:
+		return e.Clone()
+	case *Field:
+		return e.Clone()
+	case *MapField:
+		return e.Clone()
+	case *GroupField:
+		return e.Clone()
+	case *Oneof:
+		return e.Clone()
+	case *OneofField:
+		return e.Clone()
+	case *Reserved:
+		return e.Clone()
+	case *Extensions:
+		return e.Clone()
+	default:
+		return element
+	}
+}
+
+func cloneComments(comments []*Comment) []*Comment {
+	if comments == nil {
+		return nil
+	}
+	clone := make([]*Comment, 0, len(comments))
+	for _, comment := range comments {
+		clone = append(clone, comment.Clone())
+	}
+	return clone
+}
+
+func cloneOptions(options []*Option) []*Option {
+	if options == nil {
+		return nil
+	}
+	clone := make([]*Option, 0, len(options))
+	for _, option := range options {
+		clone = append(clone, option.Clone())
+	}
+	return clone
+}
+
+func cloneFieldOptions(options []*FieldOption) []*FieldOption {
+	if options == nil {
+		return nil
+	}
+	clone := make([]*FieldOption, 0, len(options))
+	for _, option := range options {
+		optionClone := *option
+		clone = append(clone, &optionClone)
+	}
+	return clone
+}
+
+func cloneRanges(ranges []*Range) []*Range {
+	if ranges == nil {
+		return nil
+	}
+	clone := make([]*Range, 0, len(ranges))
+	for _, r := range ranges {
+		rangeClone := *r
+		clone = append(clone, &rangeClone)
+	}
+	return clone
+}
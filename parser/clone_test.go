@@ -0,0 +1,147 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+// mutate overwrites every string, bool and int reachable from v, so that any value shared with another tree changes there as well.
+func mutate(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			mutate(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			mutate(v.Field(i))
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			mutate(v.Index(i))
+		}
+	case reflect.String:
+		v.SetString(v.String() + "_mutated")
+	case reflect.Bool:
+		v.SetBool(!v.Bool())
+	case reflect.Int:
+		v.SetInt(v.Int() + 1)
+	}
+}
+
+func TestProto_Clone(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name: "cloning a proto3 file",
+			input: `// Syntax.
+syntax = "proto3"; // syntax
+package foo;
+import "bar.proto";
+option go_package = "foo";
+;
+// Foo is a message.
+message Foo { // Foo
+  // a
+  int32 a = 1 [deprecated = true, json_name = "A"]; // a
+  map<string, Bar> b = 2 [deprecated = true];
+  oneof c {
+    option deprecated = true;
+    string d = 3 [deprecated = true];
+  }
+  reserved 4 to 6, 8;
+  reserved "e";
+  message Nested {
+    enum E {
+      E_A = 0 [deprecated = true];
+      ;
+    }
+  }
+}
+service S {
+  rpc R (stream Foo) returns (Foo) {
+    option deprecated = true;
+  }
+}
+`,
+		},
+		{
+			name: "cloning a proto2 file",
+			input: `syntax = "proto2";
+message Foo {
+  optional group G = 1 {
+    required int32 a = 2;
+  }
+  extensions 100 to max;
+}
+extend Foo {
+  optional int32 b = 100;
+}
+`,
+		},
+		{
+			name: "cloning an edition file",
+			input: `edition = "2023";
+message Foo {
+  int32 a = 1 [features.field_presence = IMPLICIT];
+}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			parse := func() *parser.Proto {
+				p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)), parser.WithBodyIncludingComments(true), parser.WithPermissive(true))
+				proto, err := p.ParseProto()
+				if err != nil {
+					t.Fatal(err)
+				}
+				return proto
+			}
+
+			proto := parse()
+			clone := proto.Clone()
+			if !reflect.DeepEqual(clone, proto) {
+				t.Fatalf("got %v, but want %v", clone, proto)
+			}
+
+			mutate(reflect.ValueOf(clone))
+			if !reflect.DeepEqual(proto, parse()) {
+				t.Errorf("got the original proto modified by the clone")
+			}
+		})
+	}
+}
+
+func TestMessage_Clone(t *testing.T) {
+	input := `// Foo is a message.
+message Foo { // Foo
+  int32 a = 1 [deprecated = true]; // a
+}
+`
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+	message, err := p.ParseMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := parser.Sprint(&parser.Proto{ProtoBody: []parser.Visitee{message}})
+
+	clone := message.Clone()
+	clone.MessageName = "Bar"
+	field := clone.MessageBody[0].(*parser.Field)
+	field.FieldName = "b"
+	field.FieldOptions[0].Constant = "false"
+	clone.InlineCommentBehindLeftCurly.Raw = "// Bar"
+
+	if got := parser.Sprint(&parser.Proto{ProtoBody: []parser.Visitee{message}}); got != want {
+		t.Errorf("got %q, but want %q", got, want)
+	}
+}
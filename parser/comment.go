@@ -2,6 +2,8 @@ package parser
 
 import (
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/yoheimuta/go-protoparser/v4/internal/lexer/scanner"
 	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
@@ -17,6 +19,9 @@ const (
 type Comment struct {
 	// Raw includes a comment syntax like // and /* */.
 	Raw string
+	// LeadingWhitespace is the whitespace placed before the comment syntax on the same line.
+	// It is set only when the parser is configured with WithCommentLeadingWhitespace.
+	LeadingWhitespace string
 	// Meta is the meta information.
 	Meta meta.Meta
 }
@@ -50,24 +55,63 @@ func (c *Comment) Accept(v Visitor) {
 func (p *Parser) ParseComments() []*Comment {
 	var comments []*Comment
 	for {
-		comment, err := p.parseComment()
-		if err != nil {
-			// ignores the err because the comment is optional.
+		comment, ok := p.parseComment()
+		if !ok {
 			return comments
 		}
 		comments = append(comments, comment)
 	}
 }
 
+// parseComment parses a comment. It reports false when the next token isn't a comment, which is put back.
+// It doesn't build an error since the comments are optional and the absence is the common case.
+//
 // See https://developers.google.com/protocol-buffers/docs/proto3#adding-comments
-func (p *Parser) parseComment() (*Comment, error) {
+func (p *Parser) parseComment() (*Comment, bool) {
 	p.lex.NextComment()
-	if p.lex.Token == scanner.TCOMMENT {
-		return &Comment{
-			Raw:  p.lex.Text,
-			Meta: meta.Meta{Pos: p.lex.Pos.Position},
-		}, nil
+	if p.lex.Token != scanner.TCOMMENT {
+		p.lex.UnNext()
+		return nil, false
+	}
+
+	comment := &Comment{
+		Raw: p.lex.Text,
+		Meta: meta.Meta{
+			Pos:     p.lex.Pos.Position,
+			LastPos: commentLastPos(p.lex.Pos.Position, p.lex.Text),
+		},
+	}
+	if p.commentLeadingWhitespace {
+		comment.LeadingWhitespace = leadingWhitespace(p.lex.RawText)
+	}
+	return comment, true
+}
+
+// commentLastPos returns the position of the last character of the comment which begins at pos.
+// The Offset is the one of the last byte of the character, so that Meta.ByteRange ends right after it
+// even when the character is multi-byte.
+func commentLastPos(pos meta.Position, raw string) meta.Position {
+	pos.Offset += len(raw) - 1
+	if i := strings.LastIndex(raw, "\n"); 0 <= i {
+		pos.Line += strings.Count(raw, "\n")
+		pos.Column = utf8.RuneCountInString(raw[i+1:])
+		return pos
+	}
+	pos.Column += utf8.RuneCountInString(raw) - 1
+	return pos
+}
+
+// leadingWhitespace returns the whitespace after the last newline, which precedes the comment syntax in the raw text.
+func leadingWhitespace(raw []rune) string {
+	i := 0
+	for i < len(raw) && unicode.IsSpace(raw[i]) {
+		i++
+	}
+	whitespace := raw[:i]
+	for j := len(whitespace) - 1; 0 <= j; j-- {
+		if whitespace[j] == '\n' {
+			return string(whitespace[j+1:])
+		}
 	}
-	defer p.lex.UnNext()
-	return nil, p.unexpected("comment")
+	return string(whitespace)
 }
@@ -1,6 +1,7 @@
 package parser_test
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
@@ -118,6 +119,11 @@ func TestParser_ParseComments(t *testing.T) {
 							Line:   1,
 							Column: 1,
 						},
+						LastPos: meta.Position{
+							Offset: 9,
+							Line:   1,
+							Column: 10,
+						},
 					},
 				},
 			},
@@ -136,6 +142,11 @@ func TestParser_ParseComments(t *testing.T) {
 							Line:   1,
 							Column: 1,
 						},
+						LastPos: meta.Position{
+							Offset: 9,
+							Line:   1,
+							Column: 10,
+						},
 					},
 				},
 				{
@@ -146,6 +157,11 @@ func TestParser_ParseComments(t *testing.T) {
 							Line:   2,
 							Column: 1,
 						},
+						LastPos: meta.Position{
+							Offset: 21,
+							Line:   2,
+							Column: 11,
+						},
 					},
 				},
 			},
@@ -166,6 +182,11 @@ comment
 							Line:   1,
 							Column: 1,
 						},
+						LastPos: meta.Position{
+							Offset: 12,
+							Line:   3,
+							Column: 2,
+						},
 					},
 				},
 			},
@@ -189,6 +210,11 @@ comment
 							Line:   1,
 							Column: 1,
 						},
+						LastPos: meta.Position{
+							Offset: 12,
+							Line:   3,
+							Column: 2,
+						},
 					},
 				},
 				{
@@ -201,6 +227,11 @@ comment2
 							Line:   4,
 							Column: 1,
 						},
+						LastPos: meta.Position{
+							Offset: 27,
+							Line:   6,
+							Column: 2,
+						},
 					},
 				},
 			},
@@ -224,6 +255,11 @@ comment
 							Line:   1,
 							Column: 1,
 						},
+						LastPos: meta.Position{
+							Offset: 12,
+							Line:   3,
+							Column: 2,
+						},
 					},
 				},
 				{
@@ -234,6 +270,47 @@ comment
 							Line:   5,
 							Column: 1,
 						},
+						LastPos: meta.Position{
+							Offset: 25,
+							Line:   5,
+							Column: 11,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "parsing a C-style comment immediately followed by a C++-style comment",
+			input: "/* block */// line\n",
+			wantComments: []*parser.Comment{
+				{
+					Raw: `/* block */`,
+					Meta: meta.Meta{
+						Pos: meta.Position{
+							Offset: 0,
+							Line:   1,
+							Column: 1,
+						},
+						LastPos: meta.Position{
+							Offset: 10,
+							Line:   1,
+							Column: 11,
+						},
+					},
+				},
+				{
+					Raw: `// line`,
+					Meta: meta.Meta{
+						Pos: meta.Position{
+							Offset: 11,
+							Line:   1,
+							Column: 12,
+						},
+						LastPos: meta.Position{
+							Offset: 17,
+							Line:   1,
+							Column: 18,
+						},
 					},
 				},
 			},
@@ -256,3 +333,93 @@ comment
 		})
 	}
 }
+
+func TestParser_ParseComments_withCommentLeadingWhitespace(t *testing.T) {
+	tests := []struct {
+		name                   string
+		input                  string
+		wantLeadingWhitespaces []string
+	}{
+		{
+			name:                   "parsing a C++-style comment without indentation",
+			input:                  "// comment\n",
+			wantLeadingWhitespaces: []string{""},
+		},
+		{
+			name: "parsing indented comments",
+			input: "\n" +
+				"    // comment\n" +
+				"\t/*\n" +
+				"\t * comment2\n" +
+				"\t */\n",
+			wantLeadingWhitespaces: []string{"    ", "\t"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(
+				lexer.NewLexer(strings.NewReader(test.input)),
+				parser.WithCommentLeadingWhitespace(true),
+			)
+
+			var got []string
+			for _, comment := range p.ParseComments() {
+				got = append(got, comment.LeadingWhitespace)
+			}
+			if !reflect.DeepEqual(got, test.wantLeadingWhitespaces) {
+				t.Errorf("got %q, but want %q", got, test.wantLeadingWhitespaces)
+			}
+		})
+	}
+}
+
+func TestParser_MaybeScanInlineComment_withCommentLeadingWhitespace(t *testing.T) {
+	input := `message Foo {
+  int32 a = 1;     // aligned
+  int32 bb = 10;   // aligned
+  int32 ccc = 100;	// tabbed
+}
+`
+	p := parser.NewParser(
+		lexer.NewLexer(strings.NewReader(input)),
+		parser.WithCommentLeadingWhitespace(true),
+	)
+	msg, err := p.ParseMessage()
+	if err != nil {
+		t.Fatalf("got err %v, but want nil", err)
+	}
+
+	var got []string
+	for _, body := range msg.MessageBody {
+		got = append(got, body.(*parser.Field).InlineComment.LeadingWhitespace)
+	}
+	want := []string{"     ", "   ", "\t"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, but want %q", got, want)
+	}
+}
+
+func BenchmarkParser_ParseComments(b *testing.B) {
+	var buf strings.Builder
+	buf.WriteString("syntax = \"proto3\";\n")
+	for i := 0; i < 200; i++ {
+		buf.WriteString("// Message is a message which is documented with the comments\n// spanning several lines as a heavily-commented file does.\n")
+		fmt.Fprintf(&buf, "message Message%d { // Message\n", i)
+		for j := 1; j <= 10; j++ {
+			buf.WriteString("  /*\n   * field is a field documented with a C-style comment.\n   */\n")
+			fmt.Fprintf(&buf, "  string field%d = %d; // field\n", j, j)
+		}
+		buf.WriteString("}\n")
+	}
+	input := buf.String()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+		if _, err := p.ParseProto(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
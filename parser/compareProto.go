@@ -0,0 +1,324 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
+)
+
+// ChangeKind is a kind of the change found by CompareProto.
+type ChangeKind uint
+
+// The kinds of the change.
+const (
+	ChangeKindFieldRemoved ChangeKind = iota
+	ChangeKindFieldTypeChanged
+	ChangeKindFieldNumberChanged
+	ChangeKindFieldLabelChanged
+	ChangeKindEnumValueRemoved
+	ChangeKindFieldRenamed
+)
+
+// Change is a change of a field or an enum value between two versions of a proto.
+type Change struct {
+	Kind ChangeKind
+	// Name is the fully-qualified name of the field or the enum value without the leading dot.
+	Name string
+	// Breaking reports whether the change breaks the wire compatibility.
+	Breaking bool
+	// Description describes the change, like `field "pkg.Foo.a" changed the type from "int32" to "string"`.
+	Description string
+	// Meta is the meta information of the field in the new proto. For a removed field or enum value,
+	// it's the one of the enclosing message or enum, and it's zero when the enclosing one is removed as well.
+	Meta meta.Meta
+}
+
+// CompareProto reports the changes of the message fields and the enum values from oldProto to newProto.
+// The messages, the enums and the enum values are matched by their fully-qualified names.
+// The fields are matched by their numbers, and then by their names for the ones whose numbers are gone.
+// The types of the fields are compared by the fully-qualified names which they resolve to,
+// so "Foo" and ".pkg.Foo" are the same type.
+//
+// A removed field or enum value is safe only when its number or name is reserved in the new proto.
+// A renamed field is safe, and a changed number is always breaking. A changed type is safe only between
+// the wire-compatible scalar types, such as int32 and int64, or string and bytes.
+// A changed label is safe only between no label and "optional".
+//
+// The changes are ordered as Normalize orders the declarations of the old proto.
+func CompareProto(oldProto, newProto *Proto) []Change {
+	// The unresolved type names are compared as written.
+	normalized := Normalize(oldProto)
+	oldScope, _ := Resolve(normalized)
+	newScope, _ := Resolve(newProto)
+
+	c := &protoComparer{
+		oldScope: oldScope,
+		newScope: newScope,
+		names:    make(map[Visitee]string),
+	}
+	for _, scope := range []*Scope{oldScope, newScope} {
+		for name, symbol := range scope.Symbols {
+			c.names[symbol] = "." + name
+		}
+	}
+	c.compareBody(normalized.PackageName(), normalized.ProtoBody)
+	return c.changes
+}
+
+type protoComparer struct {
+	oldScope *Scope
+	newScope *Scope
+	// names maps the declarations of both protos to their fully-qualified names with the leading dot.
+	names   map[Visitee]string
+	changes []Change
+}
+
+// typeName returns the fully-qualified name of the type which the element refers to,
+// or the type name as written when it's a scalar type or it's unresolved.
+func (c *protoComparer) typeName(scope *Scope, element interface{}, typ string) string {
+	if symbol, ok := scope.References[element]; ok {
+		return c.names[symbol]
+	}
+	return typ
+}
+
+func (c *protoComparer) add(kind ChangeKind, name string, breaking bool, m meta.Meta, format string, a ...interface{}) {
+	c.changes = append(c.changes, Change{
+		Kind:        kind,
+		Name:        name,
+		Breaking:    breaking,
+		Description: fmt.Sprintf(format, a...),
+		Meta:        m,
+	})
+}
+
+func (c *protoComparer) compareBody(scope string, body []Visitee) {
+	for _, element := range body {
+		switch e := element.(type) {
+		case *Message:
+			name := qualifyName(scope, e.MessageName)
+			c.compareMessage(name, e.MessageBody)
+			c.compareBody(name, e.MessageBody)
+		case *GroupField:
+			name := qualifyName(scope, e.GroupName)
+			c.compareMessage(name, e.MessageBody)
+			c.compareBody(name, e.MessageBody)
+		case *Enum:
+			c.compareEnum(qualifyName(scope, e.EnumName), e.EnumBody)
+		}
+	}
+}
+
+// compareMessage compares the fields of the old message body to the ones of the new message with the same name.
+func (c *protoComparer) compareMessage(messageName string, oldBody []Visitee) {
+	var newBody []Visitee
+	var newMeta meta.Meta
+	switch m := c.newScope.Symbols[messageName].(type) {
+	case *Message:
+		newBody, newMeta = m.MessageBody, m.Meta
+	case *GroupField:
+		newBody, newMeta = m.MessageBody, m.Meta
+	}
+
+	newFieldsByNumber := make(map[string]comparedField)
+	newFieldsByName := make(map[string]comparedField)
+	for _, field := range c.comparedFields(c.newScope, newBody) {
+		newFieldsByNumber[fieldNumberKey(field.number)] = field
+		newFieldsByName[field.name] = field
+	}
+
+	for _, oldField := range c.comparedFields(c.oldScope, oldBody) {
+		name := qualifyName(messageName, oldField.name)
+		newField, ok := newFieldsByNumber[fieldNumberKey(oldField.number)]
+		switch {
+		case ok && oldField.name != newField.name:
+			newName := qualifyName(messageName, newField.name)
+			c.add(ChangeKindFieldRenamed, name, false, newField.meta,
+				"field %q was renamed to %q", name, newName)
+		case !ok:
+			newField, ok = newFieldsByName[oldField.name]
+			if !ok {
+				safe := isReserved(newBody, oldField.number, oldField.name, maxFieldNumber)
+				c.add(ChangeKindFieldRemoved, name, !safe, newMeta, "field %q was removed", name)
+				continue
+			}
+			c.add(ChangeKindFieldNumberChanged, name, true, newField.meta,
+				"field %q changed the number from %s to %s", name, oldField.number, newField.number)
+		}
+		if oldField.typ != newField.typ {
+			safe := isWireCompatible(oldField.typ, newField.typ)
+			c.add(ChangeKindFieldTypeChanged, name, !safe, newField.meta,
+				"field %q changed the type from %q to %q", name, oldField.typ, newField.typ)
+		}
+		if oldField.label != newField.label {
+			safe := oldField.label != "required" && oldField.label != "repeated" &&
+				newField.label != "required" && newField.label != "repeated"
+			c.add(ChangeKindFieldLabelChanged, name, !safe, newField.meta,
+				"field %q changed the label from %q to %q", name, oldField.label, newField.label)
+		}
+	}
+}
+
+// compareEnum reports the values of the old enum body which the new enum with the same name lacks.
+func (c *protoComparer) compareEnum(enumName string, oldBody []Visitee) {
+	var newBody []Visitee
+	var newMeta meta.Meta
+	if e, ok := c.newScope.Symbols[enumName].(*Enum); ok {
+		newBody, newMeta = e.EnumBody, e.Meta
+	}
+
+	newValues := make(map[string]struct{})
+	for _, element := range newBody {
+		if field, ok := element.(*EnumField); ok {
+			newValues[field.Ident] = struct{}{}
+		}
+	}
+
+	// An enum value belongs to the scope enclosing the enum.
+	scope := parentScope(enumName)
+	for _, element := range oldBody {
+		field, ok := element.(*EnumField)
+		if !ok {
+			continue
+		}
+		if _, ok := newValues[field.Ident]; ok {
+			continue
+		}
+		name := qualifyName(scope, field.Ident)
+		safe := isReserved(newBody, field.Number, field.Ident, math.MaxInt32)
+		c.add(ChangeKindEnumValueRemoved, name, !safe, newMeta, "enum value %q was removed", name)
+	}
+}
+
+// comparedField is a field compared by CompareProto.
+type comparedField struct {
+	name   string
+	label  string
+	typ    string
+	number string
+	meta   meta.Meta
+}
+
+// comparedFields returns the fields, map fields, group fields and oneof fields in the message body.
+// The types are resolved in the scope.
+func (c *protoComparer) comparedFields(scope *Scope, body []Visitee) []comparedField {
+	var fields []comparedField
+	for _, element := range body {
+		switch e := element.(type) {
+		case *Field:
+			fields = append(fields, comparedField{
+				name:   e.FieldName,
+				label:  fieldLabel(e.IsRepeated, e.IsRequired, e.IsOptional),
+				typ:    c.typeName(scope, e, e.Type),
+				number: e.FieldNumber,
+				meta:   e.Meta,
+			})
+		case *MapField:
+			fields = append(fields, comparedField{
+				name:   e.MapName,
+				typ:    fmt.Sprintf("map<%s, %s>", e.KeyType, c.typeName(scope, e, e.Type)),
+				number: e.FieldNumber,
+				meta:   e.Meta,
+			})
+		case *GroupField:
+			fields = append(fields, comparedField{
+				name:   e.GroupName,
+				label:  fieldLabel(e.IsRepeated, e.IsRequired, e.IsOptional),
+				typ:    "group",
+				number: e.FieldNumber,
+				meta:   e.Meta,
+			})
+		case *Oneof:
+			for _, field := range e.OneofFields {
+				fields = append(fields, comparedField{
+					name:   field.FieldName,
+					typ:    c.typeName(scope, field, field.Type),
+					number: field.FieldNumber,
+					meta:   field.Meta,
+				})
+			}
+		}
+	}
+	return fields
+}
+
+// fieldNumberKey returns the number in decimal to match the fields regardless of its spelling like 0x10.
+func fieldNumberKey(number string) string {
+	n, err := strconv.ParseInt(number, 0, 64)
+	if err != nil {
+		return number
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+func fieldLabel(isRepeated, isRequired, isOptional bool) string {
+	switch {
+	case isRepeated:
+		return "repeated"
+	case isRequired:
+		return "required"
+	case isOptional:
+		return "optional"
+	default:
+		return ""
+	}
+}
+
+// wireCompatibleTypes groups the scalar types which can be changed to each other keeping the wire compatibility.
+var wireCompatibleTypes = map[string]int{
+	"int32":    1,
+	"uint32":   1,
+	"int64":    1,
+	"uint64":   1,
+	"bool":     1,
+	"sint32":   2,
+	"sint64":   2,
+	"fixed32":  3,
+	"sfixed32": 3,
+	"fixed64":  4,
+	"sfixed64": 4,
+	"string":   5,
+	"bytes":    5,
+}
+
+func isWireCompatible(oldType, newType string) bool {
+	oldGroup, ok := wireCompatibleTypes[oldType]
+	if !ok {
+		return false
+	}
+	return oldGroup == wireCompatibleTypes[newType]
+}
+
+// isReserved reports whether the body reserves the number or the name. max is the number which "max" means.
+func isReserved(body []Visitee, number string, name string, max int) bool {
+	n, err := strconv.ParseInt(number, 0, 64)
+	for _, element := range body {
+		reserved, ok := element.(*Reserved)
+		if !ok {
+			continue
+		}
+		for _, fieldName := range reserved.UnquotedFieldNames() {
+			if fieldName == name {
+				return true
+			}
+		}
+		if err != nil {
+			continue
+		}
+		for _, r := range reserved.Ranges {
+			begin, end, rerr := r.Bounds()
+			if rerr != nil {
+				continue
+			}
+			if r.IsMax() {
+				end = max
+			}
+			if begin <= int(n) && int(n) <= end {
+				return true
+			}
+		}
+	}
+	return false
+}
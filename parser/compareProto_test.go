@@ -0,0 +1,275 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestCompareProto(t *testing.T) {
+	// change is a Change with the line of the Meta.
+	type change struct {
+		kind        parser.ChangeKind
+		name        string
+		breaking    bool
+		description string
+		line        int
+	}
+
+	tests := []struct {
+		name        string
+		oldInput    string
+		newInput    string
+		wantChanges []change
+	}{
+		{
+			name: "comparing the protos which differ only in the formatting",
+			oldInput: `syntax = "proto3";
+package pkg;
+message Foo {
+  int32 a = 1;
+  string b = 2;
+}
+`,
+			newInput: `syntax = "proto3";
+package pkg;
+// Foo is reordered.
+message Foo { string b = 2; int32 a = 1; }
+`,
+		},
+		{
+			name: "comparing the changed fields",
+			oldInput: `syntax = "proto3";
+package pkg;
+message Foo {
+  int32 a = 1;
+  string b = 2;
+  int32 c = 3;
+  repeated int32 d = 4;
+  int32 e = 5;
+  message Bar {
+    int32 x = 1;
+  }
+}
+`,
+			newInput: `syntax = "proto3";
+package pkg;
+message Foo {
+  int64 a = 1;
+  bytes b = 2;
+  int32 c = 30;
+  int32 d = 4;
+  optional string e = 5;
+  message Bar {
+    int32 x = 1;
+  }
+}
+`,
+			wantChanges: []change{
+				{
+					kind:        parser.ChangeKindFieldTypeChanged,
+					name:        "pkg.Foo.a",
+					description: `field "pkg.Foo.a" changed the type from "int32" to "int64"`,
+					line:        4,
+				},
+				{
+					kind:        parser.ChangeKindFieldTypeChanged,
+					name:        "pkg.Foo.b",
+					description: `field "pkg.Foo.b" changed the type from "string" to "bytes"`,
+					line:        5,
+				},
+				{
+					kind:        parser.ChangeKindFieldNumberChanged,
+					name:        "pkg.Foo.c",
+					breaking:    true,
+					description: `field "pkg.Foo.c" changed the number from 3 to 30`,
+					line:        6,
+				},
+				{
+					kind:        parser.ChangeKindFieldLabelChanged,
+					name:        "pkg.Foo.d",
+					breaking:    true,
+					description: `field "pkg.Foo.d" changed the label from "repeated" to ""`,
+					line:        7,
+				},
+				{
+					kind:        parser.ChangeKindFieldTypeChanged,
+					name:        "pkg.Foo.e",
+					breaking:    true,
+					description: `field "pkg.Foo.e" changed the type from "int32" to "string"`,
+					line:        8,
+				},
+				{
+					kind:        parser.ChangeKindFieldLabelChanged,
+					name:        "pkg.Foo.e",
+					description: `field "pkg.Foo.e" changed the label from "" to "optional"`,
+					line:        8,
+				},
+			},
+		},
+		{
+			name: "comparing the removed fields",
+			oldInput: `syntax = "proto3";
+message Foo {
+  int32 a = 1;
+  int32 b = 2;
+  oneof o {
+    int32 c = 3;
+  }
+  map<string, int32> d = 4;
+}
+message Gone {
+  int32 x = 1;
+}
+`,
+			newInput: `syntax = "proto3";
+
+message Foo {
+  reserved 2, 4 to max;
+  reserved "c";
+}
+`,
+			wantChanges: []change{
+				{
+					kind:        parser.ChangeKindFieldRemoved,
+					name:        "Foo.a",
+					breaking:    true,
+					description: `field "Foo.a" was removed`,
+					line:        3,
+				},
+				{
+					kind:        parser.ChangeKindFieldRemoved,
+					name:        "Foo.b",
+					description: `field "Foo.b" was removed`,
+					line:        3,
+				},
+				{
+					kind:        parser.ChangeKindFieldRemoved,
+					name:        "Foo.c",
+					description: `field "Foo.c" was removed`,
+					line:        3,
+				},
+				{
+					kind:        parser.ChangeKindFieldRemoved,
+					name:        "Foo.d",
+					description: `field "Foo.d" was removed`,
+					line:        3,
+				},
+				{
+					kind:        parser.ChangeKindFieldRemoved,
+					name:        "Gone.x",
+					breaking:    true,
+					description: `field "Gone.x" was removed`,
+				},
+			},
+		},
+		{
+			name: "comparing the removed enum values",
+			oldInput: `syntax = "proto3";
+package pkg;
+message Foo {
+  enum Color {
+    RED = 0;
+    GREEN = 1;
+    BLUE = 2;
+  }
+}
+`,
+			newInput: `syntax = "proto3";
+package pkg;
+message Foo {
+  enum Color {
+    RED = 0;
+    reserved 2;
+  }
+}
+`,
+			wantChanges: []change{
+				{
+					kind:        parser.ChangeKindEnumValueRemoved,
+					name:        "pkg.Foo.GREEN",
+					breaking:    true,
+					description: `enum value "pkg.Foo.GREEN" was removed`,
+					line:        4,
+				},
+				{
+					kind:        parser.ChangeKindEnumValueRemoved,
+					name:        "pkg.Foo.BLUE",
+					description: `enum value "pkg.Foo.BLUE" was removed`,
+					line:        4,
+				},
+			},
+		},
+		{
+			name: "comparing the resolved types and the renamed fields",
+			oldInput: `syntax = "proto3";
+package pkg;
+message Foo {
+  Bar a = 1;
+  Bar b = 2;
+  int32 g = 3;
+  map<string, Bar> m = 4;
+}
+message Bar {}
+message Baz {}
+`,
+			newInput: `syntax = "proto3";
+package pkg;
+message Foo {
+  .pkg.Bar a = 1;
+  Baz b = 2;
+  int32 h = 3;
+  map<string, pkg.Bar> m = 4;
+}
+message Bar {}
+message Baz {}
+`,
+			wantChanges: []change{
+				{
+					kind:        parser.ChangeKindFieldTypeChanged,
+					name:        "pkg.Foo.b",
+					breaking:    true,
+					description: `field "pkg.Foo.b" changed the type from ".pkg.Bar" to ".pkg.Baz"`,
+					line:        5,
+				},
+				{
+					kind:        parser.ChangeKindFieldRenamed,
+					name:        "pkg.Foo.g",
+					description: `field "pkg.Foo.g" was renamed to "pkg.Foo.h"`,
+					line:        6,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			parse := func(input string) *parser.Proto {
+				p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+				proto, err := p.ParseProto()
+				if err != nil {
+					t.Fatal(err)
+				}
+				return proto
+			}
+
+			var got []change
+			for _, c := range parser.CompareProto(parse(test.oldInput), parse(test.newInput)) {
+				got = append(got, change{
+					kind:        c.Kind,
+					name:        c.Name,
+					breaking:    c.Breaking,
+					description: c.Description,
+					line:        c.Meta.Pos.Line,
+				})
+			}
+			if !reflect.DeepEqual(got, test.wantChanges) {
+				t.Errorf("got %+v, but want %+v", got, test.wantChanges)
+			}
+		})
+	}
+}
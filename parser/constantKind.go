@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"strings"
+)
+
+// ConstantKind is a kind of the option constant.
+type ConstantKind uint
+
+// The kinds of the option constant.
+const (
+	ConstantKindIdent ConstantKind = iota
+	ConstantKindBool
+	ConstantKindInt
+	ConstantKindFloat
+	ConstantKindString
+	ConstantKindAggregate
+)
+
+// Kind classifies the constant by its spelling.
+// An aggregate like `{ get: "/v1" }`, and a list like `[1, 2]` as well, is ConstantKindAggregate.
+// "inf" and "nan" are ConstantKindFloat, and an identifier other than "true" and "false" is ConstantKindIdent.
+func (o *Option) Kind() ConstantKind {
+	return constantKind(o.Constant)
+}
+
+// StringValue returns the value of the string constant with the quotes removed and the escapes interpreted,
+// and whether the constant is a string.
+func (o *Option) StringValue() (value string, ok bool) {
+	return stringValue(o.Constant)
+}
+
+// Kind classifies the constant by its spelling. See Option.Kind for the details.
+func (f *FieldOption) Kind() ConstantKind {
+	return constantKind(f.Constant)
+}
+
+// StringValue returns the value of the string constant. See Option.StringValue for the details.
+func (f *FieldOption) StringValue() (value string, ok bool) {
+	return stringValue(f.Constant)
+}
+
+// Kind classifies the constant by its spelling. See Option.Kind for the details.
+func (e *EnumValueOption) Kind() ConstantKind {
+	return constantKind(e.Constant)
+}
+
+// StringValue returns the value of the string constant. See Option.StringValue for the details.
+func (e *EnumValueOption) StringValue() (value string, ok bool) {
+	return stringValue(e.Constant)
+}
+
+func stringValue(constant string) (string, bool) {
+	if constantKind(constant) != ConstantKindString {
+		return "", false
+	}
+	value, err := UnquoteStrLit(constant)
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+func constantKind(constant string) ConstantKind {
+	if constant == "" {
+		return ConstantKindIdent
+	}
+
+	switch constant[0] {
+	case '"', '\'':
+		return ConstantKindString
+	case '{', '<', '[':
+		return ConstantKindAggregate
+	}
+	if _, ok := boolConstant(constant); ok {
+		return ConstantKindBool
+	}
+
+	number := strings.TrimLeft(constant, "+-")
+	switch {
+	case number == "inf" || number == "nan":
+		return ConstantKindFloat
+	case number == "" || !(number[0] == '.' || ('0' <= number[0] && number[0] <= '9')):
+		return ConstantKindIdent
+	case strings.HasPrefix(number, "0x") || strings.HasPrefix(number, "0X"):
+		return ConstantKindInt
+	case strings.ContainsAny(number, ".eE"):
+		return ConstantKindFloat
+	default:
+		return ConstantKindInt
+	}
+}
@@ -0,0 +1,151 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestOption_Kind(t *testing.T) {
+	tests := []struct {
+		name      string
+		constant  string
+		wantKind  parser.ConstantKind
+		wantValue string
+		wantOK    bool
+	}{
+		{
+			name:     "a fullIdent",
+			constant: "foo.bar",
+			wantKind: parser.ConstantKindIdent,
+		},
+		{
+			name:     "an ident which begins with inf",
+			constant: "infinity",
+			wantKind: parser.ConstantKindIdent,
+		},
+		{
+			name:     "a boolLit",
+			constant: "false",
+			wantKind: parser.ConstantKindBool,
+		},
+		{
+			name:     "a negative intLit",
+			constant: "-1928",
+			wantKind: parser.ConstantKindInt,
+		},
+		{
+			name:     "a hexadecimal intLit with an e",
+			constant: "0x1E",
+			wantKind: parser.ConstantKindInt,
+		},
+		{
+			name:     "an octal intLit",
+			constant: "017",
+			wantKind: parser.ConstantKindInt,
+		},
+		{
+			name:     "a floatLit with an exponent",
+			constant: "+1928e10",
+			wantKind: parser.ConstantKindFloat,
+		},
+		{
+			name:     "a floatLit starting with a dot",
+			constant: ".5",
+			wantKind: parser.ConstantKindFloat,
+		},
+		{
+			name:     "a negative inf",
+			constant: "-inf",
+			wantKind: parser.ConstantKindFloat,
+		},
+		{
+			name:     "an aggregate",
+			constant: `{get:"/v1"}`,
+			wantKind: parser.ConstantKindAggregate,
+		},
+		{
+			name:     "a list",
+			constant: `[1,2]`,
+			wantKind: parser.ConstantKindAggregate,
+		},
+		{
+			name:      "a double-quoted strLit with escapes",
+			constant:  `"a\"b\n\x41\101é\?"`,
+			wantKind:  parser.ConstantKindString,
+			wantValue: "a\"b\nAAé?",
+			wantOK:    true,
+		},
+		{
+			name:      "a single-quoted strLit",
+			constant:  `'it\'s "quoted"'`,
+			wantKind:  parser.ConstantKindString,
+			wantValue: `it's "quoted"`,
+			wantOK:    true,
+		},
+		{
+			name:     "a strLit with an invalid escape",
+			constant: `"\q"`,
+			wantKind: parser.ConstantKindString,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			option := &parser.Option{Constant: test.constant}
+
+			if got := option.Kind(); got != test.wantKind {
+				t.Errorf("got %v, but want %v", got, test.wantKind)
+			}
+
+			value, ok := option.StringValue()
+			if value != test.wantValue || ok != test.wantOK {
+				t.Errorf("got (%q, %v), but want (%q, %v)", value, ok, test.wantValue, test.wantOK)
+			}
+
+			fieldOption := &parser.FieldOption{Constant: test.constant}
+			if got := fieldOption.Kind(); got != test.wantKind {
+				t.Errorf("got %v, but want %v", got, test.wantKind)
+			}
+			value, ok = fieldOption.StringValue()
+			if value != test.wantValue || ok != test.wantOK {
+				t.Errorf("got (%q, %v), but want (%q, %v)", value, ok, test.wantValue, test.wantOK)
+			}
+
+			enumValueOption := &parser.EnumValueOption{Constant: test.constant}
+			if got := enumValueOption.Kind(); got != test.wantKind {
+				t.Errorf("got %v, but want %v", got, test.wantKind)
+			}
+			value, ok = enumValueOption.StringValue()
+			if value != test.wantValue || ok != test.wantOK {
+				t.Errorf("got (%q, %v), but want (%q, %v)", value, ok, test.wantValue, test.wantOK)
+			}
+		})
+	}
+}
+
+func TestFieldOption_Kind(t *testing.T) {
+	input := `string a = 1 [deprecated = true, json_name = 'A'];`
+	wantKinds := []parser.ConstantKind{parser.ConstantKindBool, parser.ConstantKindString}
+
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+	field, err := p.ParseField()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotKinds []parser.ConstantKind
+	for _, option := range field.FieldOptions {
+		gotKinds = append(gotKinds, option.Kind())
+	}
+	if !reflect.DeepEqual(gotKinds, wantKinds) {
+		t.Errorf("got %v, but want %v", gotKinds, wantKinds)
+	}
+	if value, ok := field.FieldOptions[1].StringValue(); value != "A" || !ok {
+		t.Errorf("got (%q, %v), but want (%q, %v)", value, ok, "A", true)
+	}
+}
@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
+)
+
+// Doc returns the documentation text of the field. See docText for the format.
+func (f *Field) Doc() string {
+	return docText(f.Comments, f.Meta.Pos)
+}
+
+// Doc returns the documentation text of the message. See docText for the format.
+func (m *Message) Doc() string {
+	return docText(m.Comments, m.Meta.Pos)
+}
+
+// Doc returns the documentation text of the enum. See docText for the format.
+func (e *Enum) Doc() string {
+	return docText(e.Comments, e.Meta.Pos)
+}
+
+// Doc returns the documentation text of the enum field. See docText for the format.
+func (f *EnumField) Doc() string {
+	return docText(f.Comments, f.Meta.Pos)
+}
+
+// Doc returns the documentation text of the service. See docText for the format.
+func (s *Service) Doc() string {
+	return docText(s.Comments, s.Meta.Pos)
+}
+
+// Doc returns the documentation text of the rpc. See docText for the format.
+func (r *RPC) Doc() string {
+	return docText(r.Comments, r.Meta.Pos)
+}
+
+// docText concatenates the lines of the leading comments attached to the element at pos, without comment syntax.
+// The comments detached from the element by a blank line are excluded.
+// A single space following // and a leading * of each line in /* ... */ are removed as well.
+func docText(comments []*Comment, pos meta.Position) string {
+	first := len(comments)
+	line := pos.Line
+	for i := len(comments) - 1; 0 <= i; i-- {
+		comment := comments[i]
+		lastLine := comment.Meta.Pos.Line + strings.Count(comment.Raw, "\n")
+		if lastLine < line-1 {
+			break
+		}
+		first = i
+		line = comment.Meta.Pos.Line
+	}
+
+	var lines []string
+	for _, comment := range comments[first:] {
+		lines = append(lines, docLines(comment)...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func docLines(comment *Comment) []string {
+	var lines []string
+	for _, line := range comment.Lines() {
+		if comment.IsCStyle() {
+			line = strings.TrimLeft(line, " \t")
+			line = strings.TrimPrefix(line, "*")
+		}
+		line = strings.TrimPrefix(line, " ")
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+	if comment.IsCStyle() {
+		for 0 < len(lines) && lines[0] == "" {
+			lines = lines[1:]
+		}
+		for 0 < len(lines) && lines[len(lines)-1] == "" {
+			lines = lines[:len(lines)-1]
+		}
+	}
+	return lines
+}
@@ -0,0 +1,160 @@
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestField_Doc(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantDoc string
+	}{
+		{
+			name: "parsing a multi-line comment excluding a detached one",
+			input: `
+message M {
+  // Detached comment.
+
+  // Query is the query string.
+  //
+  //   Indented line.
+  string query = 1; // inline comment
+}
+`,
+			wantDoc: `Query is the query string.
+
+  Indented line.`,
+		},
+		{
+			name: "parsing a C-style comment",
+			input: `
+message M {
+  /**
+   * Query is the query string.
+   * It must not be empty.
+   */
+  string query = 1;
+}
+`,
+			wantDoc: `Query is the query string.
+It must not be empty.`,
+		},
+		{
+			name: "parsing a C-style comment on the same line",
+			input: `
+message M {
+  /* Query. */ string query = 1;
+}
+`,
+			wantDoc: `Query.`,
+		},
+		{
+			name: "parsing no comments",
+			input: `
+message M {
+  string query = 1;
+}
+`,
+		},
+		{
+			name: "parsing only a detached comment",
+			input: `
+message M {
+  // Detached comment.
+
+  string query = 1;
+}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			got, err := p.ParseMessage()
+			if err != nil {
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			field := got.MessageBody[0].(*parser.Field)
+			if field.Doc() != test.wantDoc {
+				t.Errorf("got %q, but want %q", field.Doc(), test.wantDoc)
+			}
+		})
+	}
+}
+
+func TestDoc(t *testing.T) {
+	input := `
+syntax = "proto3";
+
+// Message doc.
+message M {
+  // Color doc.
+  enum Color {
+    // Red doc.
+    RED = 0;
+  }
+}
+
+// Service doc.
+service S {
+  // RPC doc.
+  rpc Search (M) returns (M);
+}
+`
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+	got, err := p.ParseProto()
+	if err != nil {
+		t.Errorf("got err %v, but want nil", err)
+		return
+	}
+
+	message := got.ProtoBody[0].(*parser.Message)
+	enum := message.MessageBody[0].(*parser.Enum)
+	service := got.ProtoBody[1].(*parser.Service)
+	tests := []struct {
+		name    string
+		gotDoc  string
+		wantDoc string
+	}{
+		{
+			name:    "message",
+			gotDoc:  message.Doc(),
+			wantDoc: "Message doc.",
+		},
+		{
+			name:    "enum",
+			gotDoc:  enum.Doc(),
+			wantDoc: "Color doc.",
+		},
+		{
+			name:    "enum field",
+			gotDoc:  enum.EnumBody[0].(*parser.EnumField).Doc(),
+			wantDoc: "Red doc.",
+		},
+		{
+			name:    "service",
+			gotDoc:  service.Doc(),
+			wantDoc: "Service doc.",
+		},
+		{
+			name:    "rpc",
+			gotDoc:  service.ServiceBody[0].(*parser.RPC).Doc(),
+			wantDoc: "RPC doc.",
+		},
+	}
+
+	for _, test := range tests {
+		if test.gotDoc != test.wantDoc {
+			t.Errorf("%s: got %q, but want %q", test.name, test.gotDoc, test.wantDoc)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer/scanner"
+	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
+)
+
+// Edition is used to define the protobuf edition in place of the syntax.
+type Edition struct {
+	// Edition is the unquoted edition, e.g. "2023".
+	Edition string
+
+	// Comments are the optional ones placed at the beginning.
+	Comments []*Comment
+	// InlineComment is the optional one placed at the ending.
+	InlineComment *Comment
+	// Meta is the meta information.
+	Meta meta.Meta
+}
+
+// SetInlineComment implements the HasInlineCommentSetter interface.
+func (e *Edition) SetInlineComment(comment *Comment) {
+	e.InlineComment = comment
+}
+
+// Accept dispatches the call to the visitor.
+func (e *Edition) Accept(v Visitor) {
+	if !v.VisitEdition(e) {
+		return
+	}
+
+	for _, comment := range e.Comments {
+		comment.Accept(v)
+	}
+	if e.InlineComment != nil {
+		e.InlineComment.Accept(v)
+	}
+}
+
+// ParseEdition parses the edition.
+//  edition = "edition" "=" strLit ";"
+//
+// See https://protobuf.dev/reference/protobuf/edition-2023-spec/#edition
+func (p *Parser) ParseEdition() (*Edition, error) {
+	p.lex.NextKeyword()
+	if p.lex.Token != scanner.TEDITION {
+		return nil, p.unexpected("edition")
+	}
+	startPos := p.lex.Pos
+
+	p.lex.Next()
+	if p.lex.Token != scanner.TEQUALS {
+		return nil, p.unexpected("=")
+	}
+
+	p.lex.NextStrLit()
+	if p.lex.Token != scanner.TSTRLIT {
+		return nil, p.unexpected("strLit")
+	}
+	edition := p.lex.Text[1 : len(p.lex.Text)-1]
+	if edition == "" || strings.TrimLeft(edition, "0123456789") != "" {
+		return nil, p.unexpected("decimal edition")
+	}
+
+	p.lex.Next()
+	if p.lex.Token != scanner.TSEMICOLON {
+		return nil, p.unexpected(";")
+	}
+
+	return &Edition{
+		Edition: edition,
+		Meta: meta.Meta{
+			Pos:     startPos.Position,
+			LastPos: p.lex.Pos.Position,
+		},
+	}, nil
+}
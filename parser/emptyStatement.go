@@ -1,9 +1,26 @@
 package parser
 
+import (
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer/scanner"
+	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
+)
+
 // EmptyStatement represents ";".
 type EmptyStatement struct {
 	// InlineComment is the optional one placed at the ending.
 	InlineComment *Comment
+	// Meta is the meta information. Both Pos and LastPos are the position of ";".
+	Meta meta.Meta
+}
+
+// newEmptyStatement creates the EmptyStatement of ";" scanned at pos.
+func newEmptyStatement(pos scanner.Position) *EmptyStatement {
+	return &EmptyStatement{
+		Meta: meta.Meta{
+			Pos:     pos.Position,
+			LastPos: pos.Position,
+		},
+	}
 }
 
 // SetInlineComment implements the HasInlineCommentSetter interface.
@@ -1,8 +1,6 @@
 package parser
 
 import (
-	"fmt"
-
 	"github.com/yoheimuta/go-protoparser/v4/internal/lexer/scanner"
 	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
 )
@@ -12,18 +10,24 @@ type parseEnumBodyStatementErr struct {
 	parseEmptyStatementErr error
 }
 
+// Error reports the error of parsing the statement as a enum field, which is more informative than the one as an emptyStatement.
 func (e *parseEnumBodyStatementErr) Error() string {
-	return fmt.Sprintf(
-		"%v:%v",
-		e.parseEnumFieldErr,
-		e.parseEmptyStatementErr,
-	)
+	return e.parseEnumFieldErr.Error()
+}
+
+// Unwrap returns the error of parsing the statement as a enum field.
+func (e *parseEnumBodyStatementErr) Unwrap() error {
+	return e.parseEnumFieldErr
 }
 
 // EnumValueOption is an option of a enumField.
 type EnumValueOption struct {
 	OptionName string
 	Constant   string
+	// RawConstant is the source text of Constant when it concatenates adjacent string literals,
+	// like `"foo" "bar"` for the Constant `"foobar"`, keeping the whitespace and the comments between them.
+	// It's empty otherwise.
+	RawConstant string
 }
 
 // EnumField is a field of enum.
@@ -158,6 +162,11 @@ func (p *Parser) parseEnumBody() (
 		token := p.lex.Token
 		p.lex.UnNext()
 
+		// option is also a valid enum value name.
+		if token == scanner.TOPTION && !p.peekIsOption() {
+			token = scanner.TIDENT
+		}
+
 		var stmt interface {
 			HasInlineCommentSetter
 			Visitee
@@ -207,7 +216,7 @@ func (p *Parser) parseEnumBody() (
 
 			emptyErr := p.lex.ReadEmptyStatement()
 			if emptyErr == nil {
-				stmt = &EmptyStatement{}
+				stmt = newEmptyStatement(p.lex.Pos)
 				break
 			}
 
@@ -222,7 +231,7 @@ func (p *Parser) parseEnumBody() (
 	}
 }
 
-// enumField = ident "=" intLit [ "[" enumValueOption { ","  enumValueOption } "]" ]";"
+// enumField = ident "=" [ "-" ] intLit [ "[" enumValueOption { ","  enumValueOption } "]" ]";"
 // See https://developers.google.com/protocol-buffers/docs/reference/proto3-spec#enum_definition
 func (p *Parser) parseEnumField() (*EnumField, error) {
 	p.lex.Next()
@@ -237,11 +246,16 @@ func (p *Parser) parseEnumField() (*EnumField, error) {
 		return nil, p.unexpected("=")
 	}
 
+	var number string
 	p.lex.NextNumberLit()
+	if p.lex.Text == "-" {
+		number = p.lex.Text
+		p.lex.NextNumberLit()
+	}
 	if p.lex.Token != scanner.TINTLIT {
 		return nil, p.unexpected("intLit")
 	}
-	number := p.lex.Text
+	number += p.lex.Text
 
 	enumValueOptions, err := p.parseEnumValueOptions()
 	if err != nil {
@@ -257,7 +271,10 @@ func (p *Parser) parseEnumField() (*EnumField, error) {
 		Ident:            ident,
 		Number:           number,
 		EnumValueOptions: enumValueOptions,
-		Meta:             meta.Meta{Pos: startPos.Position},
+		Meta: meta.Meta{
+			Pos:     startPos.Position,
+			LastPos: p.lex.Pos.Position,
+		},
 	}, nil
 }
 
@@ -311,13 +328,14 @@ func (p *Parser) parseEnumValueOption() (*EnumValueOption, error) {
 		return nil, p.unexpected("=")
 	}
 
-	constant, _, err := p.lex.ReadConstant(p.permissive)
+	constant, raw, err := p.parseRawOptionConstant()
 	if err != nil {
 		return nil, err
 	}
 
 	return &EnumValueOption{
-		OptionName: optionName,
-		Constant:   constant,
+		OptionName:  optionName,
+		Constant:    constant,
+		RawConstant: rawConstant(constant, raw),
 	}, nil
 }
@@ -56,6 +56,11 @@ func TestParser_ParseEnum(t *testing.T) {
 								Line:   2,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 52,
+								Line:   2,
+								Column: 28,
+							},
 						},
 					},
 					&parser.EnumField{
@@ -67,6 +72,11 @@ func TestParser_ParseEnum(t *testing.T) {
 								Line:   3,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 67,
+								Line:   3,
+								Column: 14,
+							},
 						},
 					},
 					&parser.EnumField{
@@ -78,6 +88,11 @@ func TestParser_ParseEnum(t *testing.T) {
 								Line:   4,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 82,
+								Line:   4,
+								Column: 14,
+							},
 						},
 					},
 					&parser.EnumField{
@@ -95,6 +110,11 @@ func TestParser_ParseEnum(t *testing.T) {
 								Line:   5,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 131,
+								Line:   5,
+								Column: 48,
+							},
 						},
 					},
 				},
@@ -140,6 +160,11 @@ func TestParser_ParseEnum(t *testing.T) {
 								Line:   2,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 107,
+								Line:   2,
+								Column: 83,
+							},
 						},
 					},
 				},
@@ -181,6 +206,11 @@ func TestParser_ParseEnum(t *testing.T) {
 										Line:   2,
 										Column: 3,
 									},
+									LastPos: meta.Position{
+										Offset: 35,
+										Line:   2,
+										Column: 11,
+									},
 								},
 							},
 						},
@@ -190,6 +220,11 @@ func TestParser_ParseEnum(t *testing.T) {
 								Line:   3,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 64,
+								Line:   3,
+								Column: 28,
+							},
 						},
 					},
 					&parser.EnumField{
@@ -204,6 +239,11 @@ func TestParser_ParseEnum(t *testing.T) {
 										Line:   4,
 										Column: 3,
 									},
+									LastPos: meta.Position{
+										Offset: 77,
+										Line:   4,
+										Column: 12,
+									},
 								},
 							},
 						},
@@ -213,6 +253,11 @@ func TestParser_ParseEnum(t *testing.T) {
 								Line:   5,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 92,
+								Line:   5,
+								Column: 14,
+							},
 						},
 					},
 				},
@@ -251,6 +296,11 @@ func TestParser_ParseEnum(t *testing.T) {
 									Line:   2,
 									Column: 30,
 								},
+								LastPos: meta.Position{
+									Offset: 86,
+									Line:   2,
+									Column: 38,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -259,6 +309,11 @@ func TestParser_ParseEnum(t *testing.T) {
 								Line:   2,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 76,
+								Line:   2,
+								Column: 28,
+							},
 						},
 					},
 					&parser.EnumField{
@@ -272,6 +327,11 @@ func TestParser_ParseEnum(t *testing.T) {
 									Line:   3,
 									Column: 16,
 								},
+								LastPos: meta.Position{
+									Offset: 112,
+									Line:   3,
+									Column: 25,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -280,6 +340,11 @@ func TestParser_ParseEnum(t *testing.T) {
 								Line:   3,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 101,
+								Line:   3,
+								Column: 14,
+							},
 						},
 					},
 				},
@@ -291,6 +356,11 @@ func TestParser_ParseEnum(t *testing.T) {
 							Line:   1,
 							Column: 26,
 						},
+						LastPos: meta.Position{
+							Offset: 47,
+							Line:   1,
+							Column: 48,
+						},
 					},
 				},
 				Meta: meta.Meta{
@@ -326,6 +396,11 @@ func TestParser_ParseEnum(t *testing.T) {
 								Line:   2,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 52,
+								Line:   2,
+								Column: 28,
+							},
 						},
 					},
 				},
@@ -364,6 +439,11 @@ func TestParser_ParseEnum(t *testing.T) {
 								Line:   2,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 52,
+								Line:   2,
+								Column: 28,
+							},
 						},
 					},
 					&parser.Comment{
@@ -374,6 +454,11 @@ func TestParser_ParseEnum(t *testing.T) {
 								Line:   3,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 76,
+								Line:   3,
+								Column: 23,
+							},
 						},
 					},
 					&parser.Comment{
@@ -384,6 +469,11 @@ func TestParser_ParseEnum(t *testing.T) {
 								Line:   4,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 104,
+								Line:   4,
+								Column: 27,
+							},
 						},
 					},
 				},
@@ -434,6 +524,11 @@ func TestParser_ParseEnum(t *testing.T) {
 								Line:   2,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 47,
+								Line:   2,
+								Column: 37,
+							},
 						},
 					},
 					&parser.Reserved{
@@ -447,6 +542,11 @@ func TestParser_ParseEnum(t *testing.T) {
 								Line:   3,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 72,
+								Line:   3,
+								Column: 24,
+							},
 						},
 					},
 				},
@@ -525,8 +625,9 @@ func TestParser_ParseEnum(t *testing.T) {
 						Number: "0",
 						EnumValueOptions: []*parser.EnumValueOption{
 							{
-								OptionName: "(custom_option)",
-								Constant:   `"this is a string on two lines"`,
+								OptionName:  "(custom_option)",
+								Constant:    `"this is a string on two lines"`,
+								RawConstant: "\"this is a \"\n                                 \"string on two lines\"",
 							},
 						},
 						Meta: meta.Meta{
@@ -535,6 +636,11 @@ func TestParser_ParseEnum(t *testing.T) {
 								Line:   2,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 141,
+								Line:   4,
+								Column: 16,
+							},
 						},
 					},
 				},
@@ -552,6 +658,325 @@ func TestParser_ParseEnum(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "parsing a negative enum value",
+			input: `enum Negative {
+  MINUS_ONE = -1;
+  ZERO = 0;
+}
+`,
+			wantEnum: &parser.Enum{
+				EnumName: "Negative",
+				EnumBody: []parser.Visitee{
+					&parser.EnumField{
+						Ident:  "MINUS_ONE",
+						Number: "-1",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 18,
+								Line:   2,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 32,
+								Line:   2,
+								Column: 17,
+							},
+						},
+					},
+					&parser.EnumField{
+						Ident:  "ZERO",
+						Number: "0",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 36,
+								Line:   3,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 44,
+								Line:   3,
+								Column: 11,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 46,
+						Line:   4,
+						Column: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "parsing option used as an enum value name",
+			input: `enum E {
+  option allow_alias = true;
+  option = 0;
+  OPTION = 0;
+}
+`,
+			wantEnum: &parser.Enum{
+				EnumName: "E",
+				EnumBody: []parser.Visitee{
+					&parser.Option{
+						OptionName: "allow_alias",
+						Constant:   "true",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 11,
+								Line:   2,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 36,
+								Line:   2,
+								Column: 28,
+							},
+						},
+					},
+					&parser.EnumField{
+						Ident:  "option",
+						Number: "0",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 40,
+								Line:   3,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 50,
+								Line:   3,
+								Column: 13,
+							},
+						},
+					},
+					&parser.EnumField{
+						Ident:  "OPTION",
+						Number: "0",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 54,
+								Line:   4,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 64,
+								Line:   4,
+								Column: 13,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 66,
+						Line:   5,
+						Column: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "parsing an invalid aggregate option without the permissive mode",
+			input: `enum EnumAllowingAlias {
+  option (my.enum_opt) = { a: 1 };
+}
+`,
+			wantErr: true,
+		},
+		{
+			name: "parsing an aggregate option",
+			input: `enum EnumAllowingAlias {
+  option (my.enum_opt) = { a: 1 b: "x" };
+  UNKNOWN = 0;
+}
+`,
+			permissive: true,
+			wantEnum: &parser.Enum{
+				EnumName: "EnumAllowingAlias",
+				EnumBody: []parser.Visitee{
+					&parser.Option{
+						OptionName: "(my.enum_opt)",
+						Constant: `{a:1
+b:"x"}`,
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 27,
+								Line:   2,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 65,
+								Line:   2,
+								Column: 41,
+							},
+						},
+					},
+					&parser.EnumField{
+						Ident:  "UNKNOWN",
+						Number: "0",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 69,
+								Line:   3,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 80,
+								Line:   3,
+								Column: 14,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 82,
+						Line:   4,
+						Column: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "parsing an aggregate enum value option",
+			input: `enum EnumAllowingAlias {
+  UNKNOWN = 0 [(my.value_opt) = { a: 1 }];
+}
+`,
+			permissive: true,
+			wantEnum: &parser.Enum{
+				EnumName: "EnumAllowingAlias",
+				EnumBody: []parser.Visitee{
+					&parser.EnumField{
+						Ident:  "UNKNOWN",
+						Number: "0",
+						EnumValueOptions: []*parser.EnumValueOption{
+							{
+								OptionName: "(my.value_opt)",
+								Constant:   "{a:1}",
+							},
+						},
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 27,
+								Line:   2,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 66,
+								Line:   2,
+								Column: 42,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 68,
+						Line:   3,
+						Column: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "parsing hexadecimal and octal enum values",
+			input: `enum Base {
+  HEX = 0x1F;
+  OCT = 017;
+  NEG_HEX = -0x2;
+}
+`,
+			wantEnum: &parser.Enum{
+				EnumName: "Base",
+				EnumBody: []parser.Visitee{
+					&parser.EnumField{
+						Ident:  "HEX",
+						Number: "0x1F",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 14,
+								Line:   2,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 24,
+								Line:   2,
+								Column: 13,
+							},
+						},
+					},
+					&parser.EnumField{
+						Ident:  "OCT",
+						Number: "017",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 28,
+								Line:   3,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 37,
+								Line:   3,
+								Column: 12,
+							},
+						},
+					},
+					&parser.EnumField{
+						Ident:  "NEG_HEX",
+						Number: "-0x2",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 41,
+								Line:   4,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 55,
+								Line:   4,
+								Column: 17,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 57,
+						Line:   5,
+						Column: 1,
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -1,20 +1,24 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"runtime"
+	"strings"
 
 	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
 )
 
 func (p *Parser) unexpected(expected string) error {
-	_, file, line, _ := runtime.Caller(1)
 	err := &meta.Error{
 		Pos:      p.lex.Pos.Position,
 		Expected: expected,
-		Found:    fmt.Sprintf("%q(Token=%v, Pos=%s)", p.lex.Text, p.lex.Token, p.lex.Pos),
+		Found:    p.lex.Text,
+	}
+	if p.lex.IsDebug() {
+		_, file, line, _ := runtime.Caller(1)
+		err.SetOccured(file, line)
 	}
-	err.SetOccured(file, line)
 	return err
 }
 
@@ -24,3 +28,59 @@ func (p *Parser) unexpectedf(
 ) error {
 	return p.unexpected(fmt.Sprintf(format, a...))
 }
+
+// Error is the error returned by ParseProto. It adds the text of the offending line to meta.Error.
+type Error struct {
+	// Pos is the position of the unexpected token.
+	Pos meta.Position
+	// Found is the text of the unexpected token. It's empty at EOF.
+	Found string
+	// LineText is the text of the line at Pos without the line break.
+	LineText string
+
+	err *meta.Error
+}
+
+// Error reports the same message as meta.Error.
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying meta.Error.
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Pretty renders the error like `foo.proto:3:8: expected [{], found "int32"`, followed by the offending line
+// and a caret under the column.
+func (e *Error) Pretty() string {
+	var caret strings.Builder
+	for i, ch := range []rune(e.LineText) {
+		if e.Pos.Column-1 <= i {
+			break
+		}
+		// Keeps tabs to align the caret regardless of the tab width.
+		if ch == '\t' {
+			caret.WriteRune('\t')
+		} else {
+			caret.WriteRune(' ')
+		}
+	}
+	caret.WriteRune('^')
+	return fmt.Sprintf("%s: %s\n%s\n%s", e.Pos, e.err.Message(), e.LineText, caret.String())
+}
+
+// withLineText converts a meta.Error into an Error with the text of the offending line.
+// Other errors are returned as is.
+func (p *Parser) withLineText(err error) error {
+	var metaErr *meta.Error
+	if !errors.As(err, &metaErr) {
+		return err
+	}
+	return &Error{
+		Pos:      metaErr.Pos,
+		Found:    metaErr.Found,
+		LineText: p.lex.LineText(metaErr.Pos.Line),
+		err:      metaErr,
+	}
+}
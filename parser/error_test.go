@@ -0,0 +1,146 @@
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestParser_ParseProto_errorMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name: "parsing a message without {",
+			input: `syntax = "proto3";
+message Foo
+  int32 a = 1;
+}
+`,
+			wantErr: `expected [{], found "int32" at foo.proto:3:3`,
+		},
+		{
+			name: "parsing a syntax without ;",
+			input: `syntax = "proto3"
+message Foo {}
+`,
+			wantErr: `expected [;], found "message" at foo.proto:2:1`,
+		},
+		{
+			name: "parsing a field without ;",
+			input: `syntax = "proto3";
+message Foo {
+  int32 a = 1
+}
+`,
+			wantErr: `expected [;], found "}" at foo.proto:4:1`,
+		},
+		{
+			name: "parsing an enum field without a number",
+			input: `syntax = "proto3";
+enum Foo {
+  A = x;
+}
+`,
+			wantErr: `expected [intLit], found "x" at foo.proto:3:7`,
+		},
+		{
+			name: "parsing a truncated message",
+			input: `syntax = "proto3";
+message Foo {
+`,
+			wantErr: `expected [fieldName], found EOF at foo.proto:3:1`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(
+				strings.NewReader(test.input),
+				lexer.WithFilename("foo.proto"),
+			))
+			_, err := p.ParseProto()
+			if err == nil {
+				t.Errorf("got err nil, but want %q", test.wantErr)
+				return
+			}
+			if err.Error() != test.wantErr {
+				t.Errorf("got %q, but want %q", err.Error(), test.wantErr)
+			}
+		})
+	}
+}
+
+func TestError_Pretty(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantPretty string
+	}{
+		{
+			name: "rendering the offending line in the middle of the input",
+			input: `syntax = "proto3";
+message Foo
+  int32 a = 1;
+}
+`,
+			wantPretty: `foo.proto:3:3: expected [{], found "int32"
+  int32 a = 1;
+  ^`,
+		},
+		{
+			name:  "rendering the offending line indented with a tab",
+			input: "syntax = \"proto3\";\nenum Foo {\n\tA = x; // comment\n}\n",
+			wantPretty: "foo.proto:3:6: expected [intLit], found \"x\"\n" +
+				"\tA = x; // comment\n" +
+				"\t    ^",
+		},
+		{
+			name: "rendering the offending line at EOF",
+			input: `syntax = "proto3";
+message Foo {
+`,
+			wantPretty: `foo.proto:3:1: expected [fieldName], found EOF
+
+^`,
+		},
+		{
+			name: "rendering the offending line after the preceding statements",
+			input: `syntax = "proto3";
+import "a.proto";
+message Foo {}
+enum Bar {
+  A = 0;
+}
+message Baz {
+  string b = 1 [(x) = "é"] }
+`,
+			wantPretty: `foo.proto:8:28: expected [;], found "}"
+  string b = 1 [(x) = "é"] }
+                           ^`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(
+				strings.NewReader(test.input),
+				lexer.WithFilename("foo.proto"),
+			))
+			_, err := p.ParseProto()
+			perr, ok := err.(*parser.Error)
+			if !ok {
+				t.Fatalf("got err %v, but want *parser.Error", err)
+			}
+			if got := perr.Pretty(); got != test.wantPretty {
+				t.Errorf("got %q, but want %q", got, test.wantPretty)
+			}
+		})
+	}
+}
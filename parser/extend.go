@@ -1,8 +1,6 @@
 package parser
 
 import (
-	"fmt"
-
 	"github.com/yoheimuta/go-protoparser/v4/internal/lexer/scanner"
 	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
 )
@@ -12,12 +10,14 @@ type parseExtendBodyStatementErr struct {
 	parseEmptyStatementErr error
 }
 
+// Error reports the error of parsing the statement as a field, which is more informative than the one as an emptyStatement.
 func (e *parseExtendBodyStatementErr) Error() string {
-	return fmt.Sprintf(
-		"%v:%v",
-		e.parseFieldErr,
-		e.parseEmptyStatementErr,
-	)
+	return e.parseFieldErr.Error()
+}
+
+// Unwrap returns the error of parsing the statement as a field.
+func (e *parseExtendBodyStatementErr) Unwrap() error {
+	return e.parseFieldErr
 }
 
 // Extend consists of a messageType and an extend body.
@@ -168,7 +168,7 @@ func (p *Parser) parseExtendBody() (
 
 			emptyErr := p.lex.ReadEmptyStatement()
 			if emptyErr == nil {
-				stmt = &EmptyStatement{}
+				stmt = newEmptyStatement(p.lex.Pos)
 				break
 			}
 
@@ -45,6 +45,11 @@ extend Foo {
 								Line:   3,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 31,
+								Line:   3,
+								Column: 18,
+							},
 						},
 					},
 				},
@@ -85,6 +90,11 @@ extend google.protobuf.MethodOptions {
 										Line:   3,
 										Column: 3,
 									},
+									LastPos: meta.Position{
+										Offset: 57,
+										Line:   3,
+										Column: 18,
+									},
 								},
 							},
 						},
@@ -94,6 +104,11 @@ extend google.protobuf.MethodOptions {
 								Line:   4,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 85,
+								Line:   4,
+								Column: 27,
+							},
 						},
 					},
 				},
@@ -9,6 +9,11 @@ import (
 type Extensions struct {
 	Ranges []*Range
 
+	// Multiline reports whether the statement spans multiple lines, as in
+	// a long list of ranges wrapped by the author. Each statement is kept as a
+	// separate node, so the grouping across statements is preserved as well.
+	Multiline bool
+
 	// Comments are the optional ones placed at the beginning.
 	Comments []*Comment
 	// InlineComment is the optional one placed at the ending.
@@ -58,7 +63,11 @@ func (p *Parser) ParseExtensions() (*Extensions, error) {
 	}
 
 	return &Extensions{
-		Ranges: ranges,
-		Meta:   meta.Meta{Pos: startPos.Position},
+		Ranges:    ranges,
+		Multiline: p.lex.Pos.Line != startPos.Line,
+		Meta: meta.Meta{
+			Pos:     startPos.Position,
+			LastPos: p.lex.Pos.Position,
+		},
 	}, nil
 }
@@ -47,6 +47,11 @@ func TestParser_ParseExtensions(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 21,
+						Line:   1,
+						Column: 22,
+					},
 				},
 			},
 		},
@@ -69,6 +74,40 @@ func TestParser_ParseExtensions(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 23,
+						Line:   1,
+						Column: 24,
+					},
+				},
+			},
+		},
+		{
+			name: "parsing ranges spread across multiple lines",
+			input: `extensions 4,
+  20 to max;`,
+			wantExtensions: &parser.Extensions{
+				Ranges: []*parser.Range{
+					{
+						Begin: "4",
+					},
+					{
+						Begin: "20",
+						End:   "max",
+					},
+				},
+				Multiline: true,
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 25,
+						Line:   2,
+						Column: 12,
+					},
 				},
 			},
 		},
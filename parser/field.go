@@ -9,6 +9,13 @@ import (
 type FieldOption struct {
 	OptionName string
 	Constant   string
+	// RawConstant is the source text of Constant when it concatenates adjacent string literals,
+	// like `"foo" "bar"` for the Constant `"foobar"`, keeping the whitespace and the comments between them.
+	// It's empty otherwise.
+	RawConstant string
+
+	// Meta is the meta information.
+	Meta meta.Meta
 }
 
 // Field is a normal field that is the basic element of a protocol buffer message.
@@ -29,6 +36,79 @@ type Field struct {
 	Meta meta.Meta
 }
 
+// BoolValue returns the boolean value of the constant and whether the constant is a boolean.
+// Only the canonical spellings, "true" and "false", are recognized.
+func (o *FieldOption) BoolValue() (value bool, ok bool) {
+	return boolConstant(o.Constant)
+}
+
+// FieldOption returns the first option of the field which has the given name, such as "packed" or "(my_option)".
+func (f *Field) FieldOption(name string) (*FieldOption, bool) {
+	for _, option := range f.FieldOptions {
+		if option.OptionName == name {
+			return option, true
+		}
+	}
+	return nil, false
+}
+
+// IsPacked returns the value of the packed option. set is false when the option is absent or its constant isn't
+// a canonical boolean. Then the encoding depends on the syntax, that is packed by default in proto3 and not in proto2.
+// Use FieldOption("packed") to inspect the option as written.
+func (f *Field) IsPacked() (value bool, set bool) {
+	option, ok := f.FieldOption("packed")
+	if !ok {
+		return false, false
+	}
+	return option.BoolValue()
+}
+
+// Default returns the constant of the default option of a proto2 field and whether the option is set.
+// The constant is returned as written, so an enum default is the bare identifier like "FOO"
+// and a string default keeps its quotes and escapes like `"a\tb"`.
+func (f *Field) Default() (string, bool) {
+	option, ok := f.FieldOption("default")
+	if !ok {
+		return "", false
+	}
+	return option.Constant, true
+}
+
+const fieldPresenceFeature = "features.field_presence"
+
+// HasExplicitPresence reports whether the singular field of the proto tracks whether it's set.
+// In proto2, every singular field has presence. In proto3, only a field with the optional label has,
+// which generates a synthetic oneof, so IsOptional means a different thing from proto2.
+// In editions, it has presence unless the features.field_presence feature is IMPLICIT.
+// The feature is set on the field, or inherited from the file option otherwise,
+// since a message can't set it.
+//
+// A singular message field always has presence as well, but it isn't distinguished from an enum field
+// without resolving the type, so it's reported by the label and the feature only.
+func (f *Field) HasExplicitPresence(proto *Proto) bool {
+	if f.IsRepeated {
+		return false
+	}
+	switch proto.SyntaxVersion() {
+	case "proto3":
+		return f.IsOptional
+	case "editions":
+		if option, ok := f.FieldOption(fieldPresenceFeature); ok {
+			return option.Constant != "IMPLICIT"
+		}
+		option, ok := proto.FileOption(fieldPresenceFeature)
+		return !ok || option.Constant != "IMPLICIT"
+	default:
+		return true
+	}
+}
+
+// IsScalar reports whether the type of the field is a scalar type rather than a message or an enum type,
+// which has to be resolved.
+func (f *Field) IsScalar() bool {
+	return IsScalarType(f.Type)
+}
+
 // SetInlineComment implements the HasInlineCommentSetter interface.
 func (f *Field) SetInlineComment(comment *Comment) {
 	f.InlineComment = comment
@@ -71,6 +151,15 @@ func (p *Parser) ParseField() (*Field, error) {
 	}
 	startPos := p.lex.Pos
 
+	if isRepeated || isRequired || isOptional {
+		p.lex.NextKeyword()
+		switch p.lex.Token {
+		case scanner.TREPEATED, scanner.TREQUIRED, scanner.TOPTIONAL:
+			return nil, p.unexpected("type after a single label")
+		}
+		p.lex.UnNext()
+	}
+
 	typeValue, _, err := p.parseType()
 	if err != nil {
 		return nil, p.unexpected("type")
@@ -110,7 +199,10 @@ func (p *Parser) ParseField() (*Field, error) {
 		FieldName:    fieldName,
 		FieldNumber:  fieldNumber,
 		FieldOptions: fieldOptions,
-		Meta:         meta.Meta{Pos: startPos.Position},
+		Meta: meta.Meta{
+			Pos:     startPos.Position,
+			LastPos: p.lex.Pos.Position,
+		},
 	}, nil
 }
 
@@ -163,6 +255,8 @@ func (p *Parser) parseFieldOptions() ([]*FieldOption, error) {
 // fieldOption = optionName "=" constant
 // See https://developers.google.com/protocol-buffers/docs/reference/proto3-spec#field
 func (p *Parser) parseFieldOption() (*FieldOption, error) {
+	startPos := p.peekPos()
+
 	optionName, err := p.parseOptionName()
 	if err != nil {
 		return nil, err
@@ -173,42 +267,26 @@ func (p *Parser) parseFieldOption() (*FieldOption, error) {
 		return nil, p.unexpected("=")
 	}
 
-	constant, err := p.parseOptionConstant()
+	constant, raw, err := p.parseRawOptionConstant()
 	if err != nil {
 		return nil, err
 	}
 
 	return &FieldOption{
-		OptionName: optionName,
-		Constant:   constant,
+		OptionName:  optionName,
+		Constant:    constant,
+		RawConstant: rawConstant(constant, raw),
+		Meta:        meta.Meta{Pos: startPos},
 	}, nil
 }
 
-var typeConstants = map[string]struct{}{
-	"double":   {},
-	"float":    {},
-	"int32":    {},
-	"int64":    {},
-	"uint32":   {},
-	"uint64":   {},
-	"sint32":   {},
-	"sint64":   {},
-	"fixed32":  {},
-	"fixed64":  {},
-	"sfixed32": {},
-	"sfixed64": {},
-	"bool":     {},
-	"string":   {},
-	"bytes":    {},
-}
-
 // type = "double" | "float" | "int32" | "int64" | "uint32" | "uint64"
 //      | "sint32" | "sint64" | "fixed32" | "fixed64" | "sfixed32" | "sfixed64"
 //      | "bool" | "string" | "bytes" | messageType | enumType
 // See https://developers.google.com/protocol-buffers/docs/reference/proto3-spec#fields
 func (p *Parser) parseType() (string, scanner.Position, error) {
 	p.lex.Next()
-	if _, ok := typeConstants[p.lex.Text]; ok {
+	if IsScalarType(p.lex.Text) {
 		return p.lex.Text, p.lex.Pos, nil
 	}
 	p.lex.UnNext()
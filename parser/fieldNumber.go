@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"strconv"
+
+	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
+)
+
+// numberedField is a field which occupies a number of the message.
+type numberedField struct {
+	name   string
+	number int
+	pos    meta.Position
+}
+
+// numberedFields returns the fields, map fields, group fields and oneof fields in the message body.
+// The fields of the nested messages aren't included because they have their own numbers.
+func numberedFields(body []Visitee) []numberedField {
+	var fields []numberedField
+	add := func(name, number string, pos meta.Position) {
+		n, err := strconv.ParseInt(number, 0, 64)
+		if err != nil {
+			return
+		}
+		fields = append(fields, numberedField{name: name, number: int(n), pos: pos})
+	}
+
+	for _, element := range body {
+		switch e := element.(type) {
+		case *Field:
+			add(e.FieldName, e.FieldNumber, e.Meta.Pos)
+		case *MapField:
+			add(e.MapName, e.FieldNumber, e.Meta.Pos)
+		case *GroupField:
+			add(e.GroupName, e.FieldNumber, e.Meta.Pos)
+		case *Oneof:
+			for _, field := range e.OneofFields {
+				add(field.FieldName, field.FieldNumber, field.Meta.Pos)
+			}
+		}
+	}
+	return fields
+}
+
+// MaxFieldNumber returns the largest field number among the fields, map fields, group fields and oneof fields.
+// It is useful to choose the number of a new field. Reserved numbers and extension ranges are ignored.
+// It returns 0 when the message has no fields.
+func (m *Message) MaxFieldNumber() int {
+	max := 0
+	for _, field := range numberedFields(m.MessageBody) {
+		if max < field.number {
+			max = field.number
+		}
+	}
+	return max
+}
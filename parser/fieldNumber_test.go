@@ -0,0 +1,95 @@
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestMessage_MaxFieldNumber(t *testing.T) {
+	tests := []struct {
+		name               string
+		input              string
+		wantMaxFieldNumber int
+	}{
+		{
+			name: "parsing an excerpt from the official reference",
+			input: `
+message outer {
+  option (my_option).a = true;
+  message inner {   // Level 2
+    int64 ival = 1;
+  }
+  repeated inner inner_message = 2;
+  EnumAllowingAlias enum_field =3;
+  map<int32, string> my_map = 4;
+}
+`,
+			wantMaxFieldNumber: 4,
+		},
+		{
+			name: "parsing oneof fields and ignoring reserved numbers and nested messages",
+			input: `
+message outer {
+  message inner {
+    int64 ival = 100;
+  }
+  string name = 0x10;
+  oneof test_oneof {
+    string first = 20;
+    inner second = 9;
+  }
+  reserved 30 to max;
+  extensions 200 to 300;
+}
+`,
+			wantMaxFieldNumber: 20,
+		},
+		{
+			name: "parsing a group field",
+			input: `
+message SearchResponse {
+  optional string query = 1;
+  repeated group Result = 2 {
+    required string url = 3;
+  }
+}
+`,
+			wantMaxFieldNumber: 2,
+		},
+		{
+			name: "parsing an empty message",
+			input: `
+message outer {}
+`,
+		},
+		{
+			name: "parsing a hexadecimal field number",
+			input: `
+message outer {
+  string name = 0x20;
+  string other = 20;
+}
+`,
+			wantMaxFieldNumber: 32,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			got, err := p.ParseMessage()
+			if err != nil {
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			if got.MaxFieldNumber() != test.wantMaxFieldNumber {
+				t.Errorf("got %d, but want %d", got.MaxFieldNumber(), test.wantMaxFieldNumber)
+			}
+		})
+	}
+}
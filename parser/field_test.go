@@ -46,6 +46,11 @@ func TestParser_ParseField(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 26,
+						Line:   1,
+						Column: 27,
+					},
 				},
 			},
 		},
@@ -61,6 +66,13 @@ func TestParser_ParseField(t *testing.T) {
 					{
 						OptionName: "packed",
 						Constant:   "true",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 28,
+								Line:   1,
+								Column: 29,
+							},
+						},
 					},
 				},
 				Meta: meta.Meta{
@@ -69,6 +81,11 @@ func TestParser_ParseField(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 40,
+						Line:   1,
+						Column: 41,
+					},
 				},
 			},
 		},
@@ -84,10 +101,24 @@ func TestParser_ParseField(t *testing.T) {
 					{
 						OptionName: "packed",
 						Constant:   "true",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 28,
+								Line:   1,
+								Column: 29,
+							},
+						},
 					},
 					{
 						OptionName: "required",
 						Constant:   "false",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 41,
+								Line:   1,
+								Column: 42,
+							},
+						},
 					},
 				},
 				Meta: meta.Meta{
@@ -96,6 +127,11 @@ func TestParser_ParseField(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 56,
+						Line:   1,
+						Column: 57,
+					},
 				},
 			},
 		},
@@ -116,6 +152,13 @@ func TestParser_ParseField(t *testing.T) {
 					{
 						OptionName: "(validator.field)",
 						Constant:   "{int_gt:0}",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 25,
+								Line:   1,
+								Column: 26,
+							},
+						},
 					},
 				},
 				Meta: meta.Meta{
@@ -124,6 +167,11 @@ func TestParser_ParseField(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 57,
+						Line:   1,
+						Column: 58,
+					},
 				},
 			},
 		},
@@ -139,6 +187,13 @@ func TestParser_ParseField(t *testing.T) {
 					{
 						OptionName: "(validator.field)",
 						Constant:   "{int_gt:0,}",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 25,
+								Line:   1,
+								Column: 26,
+							},
+						},
 					},
 				},
 				Meta: meta.Meta{
@@ -147,6 +202,11 @@ func TestParser_ParseField(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 58,
+						Line:   1,
+						Column: 59,
+					},
 				},
 			},
 		},
@@ -162,10 +222,24 @@ func TestParser_ParseField(t *testing.T) {
 					{
 						OptionName: "(validator.field)",
 						Constant:   "{length_gt:0,length_lt:1025}",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 18,
+								Line:   1,
+								Column: 19,
+							},
+						},
 					},
 					{
 						OptionName: "(validator.field)",
 						Constant:   `{regex:"[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{12}"}`,
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 70,
+								Line:   1,
+								Column: 71,
+							},
+						},
 					},
 				},
 				Meta: meta.Meta{
@@ -174,6 +248,11 @@ func TestParser_ParseField(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 177,
+						Line:   1,
+						Column: 178,
+					},
 				},
 			},
 		},
@@ -191,6 +270,11 @@ func TestParser_ParseField(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 35,
+						Line:   1,
+						Column: 36,
+					},
 				},
 			},
 		},
@@ -206,6 +290,13 @@ func TestParser_ParseField(t *testing.T) {
 					{
 						OptionName: "packed",
 						Constant:   "true",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 28,
+								Line:   1,
+								Column: 29,
+							},
+						},
 					},
 				},
 				Meta: meta.Meta{
@@ -214,6 +305,11 @@ func TestParser_ParseField(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 40,
+						Line:   1,
+						Column: 41,
+					},
 				},
 			},
 		},
@@ -238,6 +334,13 @@ func TestParser_ParseField(t *testing.T) {
 max_length:254
 min_length:1
 description:"Enter user email"}`,
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 20,
+								Line:   1,
+								Column: 21,
+							},
+						},
 					},
 				},
 				Meta: meta.Meta{
@@ -246,6 +349,11 @@ description:"Enter user email"}`,
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 211,
+						Line:   6,
+						Column: 3,
+					},
 				},
 			},
 		},
@@ -261,6 +369,318 @@ description:"Enter user email"}`,
 					{
 						OptionName: "(grpc.gateway.protoc_gen_swagger.options.openapiv2_field)",
 						Constant:   `{description:"Float value field",default:"0.2",required:['float_value']}`,
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 23,
+								Line:   1,
+								Column: 24,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 161,
+						Line:   1,
+						Column: 162,
+					},
+				},
+			},
+		},
+		{
+			name:  "parsing fieldOptions with negative numeric constants",
+			input: `int32 value = 1 [(range).min = -5, (range).max = -1.5, (range).step = +2];`,
+			wantField: &parser.Field{
+				Type:        "int32",
+				FieldName:   "value",
+				FieldNumber: "1",
+				FieldOptions: []*parser.FieldOption{
+					{
+						OptionName: "(range).min",
+						Constant:   "-5",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 17,
+								Line:   1,
+								Column: 18,
+							},
+						},
+					},
+					{
+						OptionName: "(range).max",
+						Constant:   "-1.5",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 35,
+								Line:   1,
+								Column: 36,
+							},
+						},
+					},
+					{
+						OptionName: "(range).step",
+						Constant:   "+2",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 55,
+								Line:   1,
+								Column: 56,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 73,
+						Line:   1,
+						Column: 74,
+					},
+				},
+			},
+		},
+		{
+			name:       "parsing fieldOption constant with { and negative numeric constants by permissive mode",
+			input:      `double value = 1 [(range) = {min: -5, max: -1.5e3, values: [-1, -inf]}];`,
+			permissive: true,
+			wantField: &parser.Field{
+				Type:        "double",
+				FieldName:   "value",
+				FieldNumber: "1",
+				FieldOptions: []*parser.FieldOption{
+					{
+						OptionName: "(range)",
+						Constant:   "{min:-5,max:-1.5e3,values:[-1,-inf]}",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 18,
+								Line:   1,
+								Column: 19,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 71,
+						Line:   1,
+						Column: 72,
+					},
+				},
+			},
+		},
+		{
+			name:    "parsing an invalid; a sign without a number",
+			input:   `int32 value = 1 [(range).min = -foo];`,
+			wantErr: true,
+		},
+		{
+			name:    "parsing an invalid; multiple labels",
+			input:   `optional required int32 samples = 4;`,
+			wantErr: true,
+		},
+		{
+			name:    "parsing an invalid; a duplicated label",
+			input:   `repeated repeated int32 samples = 4;`,
+			wantErr: true,
+		},
+		{
+			name:  "parsing feature options introduced by editions",
+			input: "int32 a = 1 [features.field_presence = EXPLICIT, features.(pb.cpp).string_type = VIEW];",
+			wantField: &parser.Field{
+				Type:        "int32",
+				FieldName:   "a",
+				FieldNumber: "1",
+				FieldOptions: []*parser.FieldOption{
+					{
+						OptionName: "features.field_presence",
+						Constant:   "EXPLICIT",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 13,
+								Line:   1,
+								Column: 14,
+							},
+						},
+					},
+					{
+						OptionName: "features.(pb.cpp).string_type",
+						Constant:   "VIEW",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 49,
+								Line:   1,
+								Column: 50,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 86,
+						Line:   1,
+						Column: 87,
+					},
+				},
+			},
+		},
+		{
+			name:    "parsing an option name with an empty part after a dot",
+			input:   "int32 a = 1 [features.(pb.cpp). = VIEW];",
+			wantErr: true,
+		},
+		{
+			name:  "parsing a default constant concatenating string literals",
+			input: `optional string s = 1 [default = "foo" 'bar'];`,
+			wantField: &parser.Field{
+				IsOptional:  true,
+				Type:        "string",
+				FieldName:   "s",
+				FieldNumber: "1",
+				FieldOptions: []*parser.FieldOption{
+					{
+						OptionName:  "default",
+						Constant:    `"foobar"`,
+						RawConstant: `"foo" 'bar'`,
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 23,
+								Line:   1,
+								Column: 24,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 45,
+						Line:   1,
+						Column: 46,
+					},
+				},
+			},
+		},
+		{
+			name:  "parsing multiple fieldOptions on a single line",
+			input: `int32 x = 1 [(a) = 1, (b) = 2, deprecated = true];`,
+			wantField: &parser.Field{
+				Type:        "int32",
+				FieldName:   "x",
+				FieldNumber: "1",
+				FieldOptions: []*parser.FieldOption{
+					{
+						OptionName: "(a)",
+						Constant:   "1",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 13,
+								Line:   1,
+								Column: 14,
+							},
+						},
+					},
+					{
+						OptionName: "(b)",
+						Constant:   "2",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 22,
+								Line:   1,
+								Column: 23,
+							},
+						},
+					},
+					{
+						OptionName: "deprecated",
+						Constant:   "true",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 31,
+								Line:   1,
+								Column: 32,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 49,
+						Line:   1,
+						Column: 50,
+					},
+				},
+			},
+		},
+		{
+			name:       "parsing fieldOptions which have commas in nested aggregates and lists by permissive mode",
+			input:      `string body = 1 [(a) = { get: "/v1", body: "*", additional_bindings { post: "/x", body: "b" } }, (b) = { ids: [1, 2], m: { k: 1, j: 2 } }, deprecated = true];`,
+			permissive: true,
+			wantField: &parser.Field{
+				Type:        "string",
+				FieldName:   "body",
+				FieldNumber: "1",
+				FieldOptions: []*parser.FieldOption{
+					{
+						OptionName: "(a)",
+						Constant:   `{get:"/v1",body:"*",additional_bindings{post:"/x",body:"b"}}`,
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 17,
+								Line:   1,
+								Column: 18,
+							},
+						},
+					},
+					{
+						OptionName: "(b)",
+						Constant:   "{ids:[1,2],m:{k:1,j:2}}",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 97,
+								Line:   1,
+								Column: 98,
+							},
+						},
+					},
+					{
+						OptionName: "deprecated",
+						Constant:   "true",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 139,
+								Line:   1,
+								Column: 140,
+							},
+						},
 					},
 				},
 				Meta: meta.Meta{
@@ -269,6 +689,11 @@ description:"Enter user email"}`,
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 157,
+						Line:   1,
+						Column: 158,
+					},
 				},
 			},
 		},
@@ -301,3 +726,202 @@ description:"Enter user email"}`,
 	}
 
 }
+
+func TestField_IsPacked(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantValue bool
+		wantSet   bool
+		wantFound bool
+	}{
+		{
+			name:      "parsing an explicitly packed field",
+			input:     "repeated int32 samples = 4 [packed=true];",
+			wantValue: true,
+			wantSet:   true,
+			wantFound: true,
+		},
+		{
+			name:      "parsing an explicitly unpacked field among other options",
+			input:     "repeated int32 samples = 4 [deprecated=true, packed=false];",
+			wantSet:   true,
+			wantFound: true,
+		},
+		{
+			name:      "parsing a non-boolean packed option",
+			input:     "repeated int32 samples = 4 [packed=True];",
+			wantFound: true,
+		},
+		{
+			name:  "parsing a field without the packed option",
+			input: "repeated int32 samples = 4;",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			field, err := p.ParseField()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, found := field.FieldOption("packed")
+			if found != test.wantFound {
+				t.Errorf("got %v, but want %v", found, test.wantFound)
+			}
+
+			value, set := field.IsPacked()
+			if value != test.wantValue || set != test.wantSet {
+				t.Errorf("got (%v, %v), but want (%v, %v)", value, set, test.wantValue, test.wantSet)
+			}
+		})
+	}
+}
+
+func TestField_Default(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantValue string
+		wantSet   bool
+	}{
+		{
+			name:      "parsing an int default",
+			input:     "optional int32 x = 1 [default = 5];",
+			wantValue: "5",
+			wantSet:   true,
+		},
+		{
+			name:      "parsing a negative float default among other options",
+			input:     "optional double x = 1 [deprecated = true, default = -1.5e3];",
+			wantValue: "-1.5e3",
+			wantSet:   true,
+		},
+		{
+			name:      "parsing an enum default",
+			input:     "optional Color x = 1 [default = RED];",
+			wantValue: "RED",
+			wantSet:   true,
+		},
+		{
+			name:      "parsing a string default with an escape",
+			input:     `optional string x = 1 [default = "a\tb"];`,
+			wantValue: `"a\tb"`,
+			wantSet:   true,
+		},
+		{
+			name:  "parsing a field without the default option",
+			input: "optional int32 x = 1 [packed = true];",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			field, err := p.ParseField()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			value, set := field.Default()
+			if value != test.wantValue || set != test.wantSet {
+				t.Errorf("got (%q, %v), but want (%q, %v)", value, set, test.wantValue, test.wantSet)
+			}
+		})
+	}
+}
+
+func TestField_HasExplicitPresence(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantPresence map[string]bool
+	}{
+		{
+			name: "parsing proto3 fields",
+			input: `syntax = "proto3";
+message Foo {
+  int32 a = 1;
+  optional int32 b = 2;
+  repeated int32 c = 3;
+}
+`,
+			wantPresence: map[string]bool{
+				"a": false,
+				"b": true,
+				"c": false,
+			},
+		},
+		{
+			name: "parsing proto2 fields",
+			input: `syntax = "proto2";
+message Foo {
+  optional int32 a = 1;
+  required int32 b = 2;
+  repeated int32 c = 3;
+}
+`,
+			wantPresence: map[string]bool{
+				"a": true,
+				"b": true,
+				"c": false,
+			},
+		},
+		{
+			name: "parsing editions fields",
+			input: `edition = "2023";
+message Foo {
+  int32 a = 1;
+  int32 b = 2 [features.field_presence = IMPLICIT];
+  repeated int32 c = 3;
+}
+`,
+			wantPresence: map[string]bool{
+				"a": true,
+				"b": false,
+				"c": false,
+			},
+		},
+		{
+			name: "parsing editions fields inheriting the file-level feature",
+			input: `edition = "2023";
+option features.field_presence = IMPLICIT;
+message Foo {
+  int32 a = 1;
+  int32 b = 2 [features.field_presence = EXPLICIT];
+  int32 c = 3 [features.field_presence = LEGACY_REQUIRED];
+}
+`,
+			wantPresence: map[string]bool{
+				"a": false,
+				"b": true,
+				"c": true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			proto, err := p.ParseProto()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			message := proto.ProtoBody[len(proto.ProtoBody)-1].(*parser.Message)
+			gotPresence := make(map[string]bool)
+			for _, element := range message.MessageBody {
+				field := element.(*parser.Field)
+				gotPresence[field.FieldName] = field.HasExplicitPresence(proto)
+			}
+			if !reflect.DeepEqual(gotPresence, test.wantPresence) {
+				t.Errorf("got %v, but want %v", gotPresence, test.wantPresence)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package parser
+
+const httpRuleOptionName = "(google.api.http)"
+
+// HTTPRule is a mapping of an RPC to an HTTP REST API method, specified by the google.api.http option.
+//
+// See https://cloud.google.com/endpoints/docs/grpc-service-config/reference/rpc/google.api#httprule
+type HTTPRule struct {
+	// Method is one of "get", "put", "post", "delete" and "patch", or the kind of a custom pattern.
+	Method string
+	// Path is the unquoted URL path template.
+	Path string
+	// Body is the unquoted name of the request field mapped to the HTTP request body, if any.
+	Body string
+	// ResponseBody is the unquoted name of the response field mapped to the HTTP response body, if any.
+	ResponseBody string
+}
+
+// HTTPRules interprets the google.api.http option into HTTPRules.
+// The first element is the primary rule, which is followed by the additional_bindings in order.
+// It returns nil when the option doesn't exist or can't be interpreted.
+func (r *RPC) HTTPRules() []HTTPRule {
+	for _, option := range r.Options {
+		if option.OptionName != httpRuleOptionName {
+			continue
+		}
+
+		constant, err := option.StructuredConstant()
+		if err != nil || !constant.IsAggregate() {
+			return nil
+		}
+		return interpretHTTPRules(constant.Fields)
+	}
+	return nil
+}
+
+func interpretHTTPRules(fields map[string][]*OptionConstant) []HTTPRule {
+	var rule HTTPRule
+	for _, method := range []string{"get", "put", "post", "delete", "patch"} {
+		if path := firstScalar(fields[method]); path != "" {
+			rule.Method = method
+			rule.Path = unquote(path)
+		}
+	}
+	for _, custom := range fields["custom"] {
+		rule.Method = unquote(firstScalar(custom.Fields["kind"]))
+		rule.Path = unquote(firstScalar(custom.Fields["path"]))
+	}
+	rule.Body = unquote(firstScalar(fields["body"]))
+	rule.ResponseBody = unquote(firstScalar(fields["response_body"]))
+
+	rules := []HTTPRule{rule}
+	for _, additional := range fields["additional_bindings"] {
+		rules = append(rules, interpretHTTPRules(additional.Fields)...)
+	}
+	return rules
+}
+
+// firstScalar returns the Scalar of the first value, or an empty string when there are no values.
+func firstScalar(values []*OptionConstant) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0].Scalar
+}
@@ -0,0 +1,128 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestRPC_HTTPRules(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		wantHTTPRules []parser.HTTPRule
+	}{
+		{
+			name: "parsing no google.api.http option",
+			input: `
+service Foo {
+  rpc Get (GetRequest) returns (GetResponse) {
+    option deprecated = true;
+  };
+}`,
+		},
+		{
+			name: "parsing a post rule and an additional binding",
+			input: `
+service Foo {
+  rpc Create (CreateRequest) returns (CreateResponse) {
+    option (google.api.http) = {
+      post: "/v1/resources"
+      body: "*"
+      additional_bindings {
+        get: "/v1/resources/{id}"
+      }
+    };
+  };
+}`,
+			wantHTTPRules: []parser.HTTPRule{
+				{
+					Method: "post",
+					Path:   "/v1/resources",
+					Body:   "*",
+				},
+				{
+					Method: "get",
+					Path:   "/v1/resources/{id}",
+				},
+			},
+		},
+		{
+			name: "parsing a custom rule and listed additional bindings",
+			input: `
+service Foo {
+  rpc Update (UpdateRequest) returns (UpdateResponse) {
+    option (google.api.http) = {
+      custom: {
+        kind: "HEAD"
+        path: "/v1/resources/{id}"
+      }
+      additional_bindings: [
+        {
+          patch: "/v1/resources/{id}"
+          body: "resource"
+        },
+        {
+          put: '/v2/resources/{id}'
+          body: "*"
+        }
+      ]
+    };
+  };
+}`,
+			wantHTTPRules: []parser.HTTPRule{
+				{
+					Method: "HEAD",
+					Path:   "/v1/resources/{id}",
+				},
+				{
+					Method: "patch",
+					Path:   "/v1/resources/{id}",
+					Body:   "resource",
+				},
+				{
+					Method: "put",
+					Path:   "/v2/resources/{id}",
+					Body:   "*",
+				},
+			},
+		},
+		{
+			name: "parsing a templated path, a response body and the separators",
+			input: `
+service Foo {
+  rpc Cancel (CancelRequest) returns (CancelResponse) {
+    option (google.api.http) = { post: "/v1/{name=projects/*/operations/**}:cancel", body: "*"; response_body: "operation" };
+  }
+}`,
+			wantHTTPRules: []parser.HTTPRule{
+				{
+					Method:       "post",
+					Path:         "/v1/{name=projects/*/operations/**}:cancel",
+					Body:         "*",
+					ResponseBody: "operation",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)), parser.WithPermissive(true))
+			service, err := p.ParseService()
+			if err != nil {
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			got := service.ServiceBody[0].(*parser.RPC).HTTPRules()
+			if !reflect.DeepEqual(got, test.wantHTTPRules) {
+				t.Errorf("got %v, but want %v", got, test.wantHTTPRules)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"strings"
+
 	"github.com/yoheimuta/go-protoparser/v4/internal/lexer/scanner"
 	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
 )
@@ -18,6 +20,8 @@ const (
 // Import is used to import another .proto's definitions.
 type Import struct {
 	Modifier ImportModifier
+	// Location keeps the surrounding quotes as written, e.g. "other.proto" including the quotes.
+	// Use UnquotedLocation to get the clean path.
 	Location string
 
 	// Comments are the optional ones placed at the beginning.
@@ -33,6 +37,17 @@ func (i *Import) SetInlineComment(comment *Comment) {
 	i.InlineComment = comment
 }
 
+// UnquotedLocation returns the Location without the surrounding quotes, e.g. other.proto.
+func (i *Import) UnquotedLocation() string {
+	return unquote(i.Location)
+}
+
+// IsWellKnown returns true if the import refers to a well-known type file like "google/protobuf/timestamp.proto",
+// which is bundled with protoc rather than defined by the project.
+func (i *Import) IsWellKnown() bool {
+	return strings.HasPrefix(i.UnquotedLocation(), "google/protobuf/")
+}
+
 // Accept dispatches the call to the visitor.
 func (i *Import) Accept(v Visitor) {
 	if !v.VisitImport(i) {
@@ -68,6 +83,8 @@ func (p *Parser) ParseImport() (*Import, error) {
 	case scanner.TSTRLIT:
 		modifier = ImportModifierNone
 		p.lex.UnNext()
+	default:
+		return nil, p.unexpected(`"weak", "public" or strLit`)
 	}
 
 	p.lex.NextStrLit()
@@ -84,6 +101,9 @@ func (p *Parser) ParseImport() (*Import, error) {
 	return &Import{
 		Modifier: modifier,
 		Location: location,
-		Meta:     meta.Meta{Pos: startPos.Position},
+		Meta: meta.Meta{
+			Pos:     startPos.Position,
+			LastPos: p.lex.Pos.Position,
+		},
 	}, nil
 }
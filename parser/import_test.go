@@ -43,6 +43,11 @@ func TestParser_ParseImport(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 40,
+						Line:   1,
+						Column: 41,
+					},
 				},
 			},
 		},
@@ -58,6 +63,11 @@ func TestParser_ParseImport(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 27,
+						Line:   1,
+						Column: 28,
+					},
 				},
 			},
 		},
@@ -73,9 +83,19 @@ func TestParser_ParseImport(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 25,
+						Line:   1,
+						Column: 26,
+					},
 				},
 			},
 		},
+		{
+			name:    "parsing the invalid statement with an unknown modifier",
+			input:   `import private "other.proto";`,
+			wantErr: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -105,3 +125,35 @@ func TestParser_ParseImport(t *testing.T) {
 	}
 
 }
+
+func TestImport_UnquotedLocation(t *testing.T) {
+	tests := []struct {
+		name                 string
+		inputLocation        string
+		wantUnquotedLocation string
+	}{
+		{
+			name:                 "double-quoted",
+			inputLocation:        `"other.proto"`,
+			wantUnquotedLocation: "other.proto",
+		},
+		{
+			name:                 "single-quoted",
+			inputLocation:        `'google/protobuf/empty.proto'`,
+			wantUnquotedLocation: "google/protobuf/empty.proto",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			i := &parser.Import{
+				Location: test.inputLocation,
+			}
+			got := i.UnquotedLocation()
+			if got != test.wantUnquotedLocation {
+				t.Errorf("got %q, but want %q", got, test.wantUnquotedLocation)
+			}
+		})
+	}
+}
@@ -40,6 +40,11 @@ func TestParser_MaybeScanInlineComment(t *testing.T) {
 						Line:   1,
 						Column: 25,
 					},
+					LastPos: meta.Position{
+						Offset: 55,
+						Line:   1,
+						Column: 56,
+					},
 				},
 			},
 		},
@@ -57,6 +62,11 @@ func TestParser_MaybeScanInlineComment(t *testing.T) {
 						Line:   1,
 						Column: 25,
 					},
+					LastPos: meta.Position{
+						Offset: 58,
+						Line:   2,
+						Column: 2,
+					},
 				},
 			},
 		},
@@ -86,6 +96,11 @@ func TestParser_MaybeScanInlineComment(t *testing.T) {
 						Line:   1,
 						Column: 25,
 					},
+					LastPos: meta.Position{
+						Offset: 55,
+						Line:   1,
+						Column: 56,
+					},
 				},
 			},
 		},
@@ -105,6 +120,11 @@ func TestParser_MaybeScanInlineComment(t *testing.T) {
 						Line:   1,
 						Column: 25,
 					},
+					LastPos: meta.Position{
+						Offset: 58,
+						Line:   2,
+						Column: 2,
+					},
 				},
 			},
 		},
@@ -45,9 +45,65 @@ func TestParser_ParseMapField(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 33,
+						Line:   1,
+						Column: 34,
+					},
+				},
+			},
+		},
+		{
+			name:  "parsing fieldOptions",
+			input: `map<string,string> m = 1 [deprecated = true, (my.opt) = "x"];`,
+			wantMapField: &parser.MapField{
+				KeyType:     "string",
+				Type:        "string",
+				MapName:     "m",
+				FieldNumber: "1",
+				FieldOptions: []*parser.FieldOption{
+					{
+						OptionName: "deprecated",
+						Constant:   "true",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 26,
+								Line:   1,
+								Column: 27,
+							},
+						},
+					},
+					{
+						OptionName: "(my.opt)",
+						Constant:   `"x"`,
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 45,
+								Line:   1,
+								Column: 46,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 60,
+						Line:   1,
+						Column: 61,
+					},
 				},
 			},
 		},
+		{
+			name:    "parsing an invalid; fieldOptions without ]",
+			input:   "map<string, string> m = 1 [deprecated = true;",
+			wantErr: true,
+		},
 	}
 
 	for _, test := range tests {
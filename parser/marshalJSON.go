@@ -0,0 +1,175 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// The nodes are marshaled into JSON objects which have the "type" discriminator, like {"type":"Message",...},
+// followed by their fields including Meta. So the elements of a body such as MessageBody can be told apart.
+// The Type fields of Field and OneofField are marshaled as "FieldType", and the one of MapField as "ValueType",
+// since encoding/json matches the keys to the struct fields case-insensitively and "Type" would be taken as "type".
+// Each method converts the node to a plain type, which has the same fields and no methods, to avoid the recursion.
+
+// MarshalJSON implements the json.Marshaler interface.
+func (p *Proto) MarshalJSON() ([]byte, error) {
+	type plain Proto
+	return marshalJSONWithType("Proto", (*plain)(p))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (c *Comment) MarshalJSON() ([]byte, error) {
+	type plain Comment
+	return marshalJSONWithType("Comment", (*plain)(c))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e *EmptyStatement) MarshalJSON() ([]byte, error) {
+	type plain EmptyStatement
+	return marshalJSONWithType("EmptyStatement", (*plain)(e))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s *Syntax) MarshalJSON() ([]byte, error) {
+	type plain Syntax
+	return marshalJSONWithType("Syntax", (*plain)(s))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e *Edition) MarshalJSON() ([]byte, error) {
+	type plain Edition
+	return marshalJSONWithType("Edition", (*plain)(e))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (i *Import) MarshalJSON() ([]byte, error) {
+	type plain Import
+	return marshalJSONWithType("Import", (*plain)(i))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (p *Package) MarshalJSON() ([]byte, error) {
+	type plain Package
+	return marshalJSONWithType("Package", (*plain)(p))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (o *Option) MarshalJSON() ([]byte, error) {
+	type plain Option
+	return marshalJSONWithType("Option", (*plain)(o))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m *Message) MarshalJSON() ([]byte, error) {
+	type plain Message
+	return marshalJSONWithType("Message", (*plain)(m))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e *Enum) MarshalJSON() ([]byte, error) {
+	type plain Enum
+	return marshalJSONWithType("Enum", (*plain)(e))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f *EnumField) MarshalJSON() ([]byte, error) {
+	type plain EnumField
+	return marshalJSONWithType("EnumField", (*plain)(f))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s *Service) MarshalJSON() ([]byte, error) {
+	type plain Service
+	return marshalJSONWithType("Service", (*plain)(s))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (r *RPC) MarshalJSON() ([]byte, error) {
+	type plain RPC
+	return marshalJSONWithType("RPC", (*plain)(r))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m *Extend) MarshalJSON() ([]byte, error) {
+	type plain Extend
+	return marshalJSONWithType("Extend", (*plain)(m))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f *Field) MarshalJSON() ([]byte, error) {
+	type plain Field
+	return marshalJSONWithType("Field", &struct {
+		FieldType string
+		*plain
+		// Type hides the one of plain, being shallower and omitted.
+		Type string `json:",omitempty"`
+	}{
+		FieldType: f.Type,
+		plain:     (*plain)(f),
+	})
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m *MapField) MarshalJSON() ([]byte, error) {
+	type plain MapField
+	return marshalJSONWithType("MapField", &struct {
+		ValueType string
+		*plain
+		// Type hides the one of plain, being shallower and omitted.
+		Type string `json:",omitempty"`
+	}{
+		ValueType: m.Type,
+		plain:     (*plain)(m),
+	})
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f *GroupField) MarshalJSON() ([]byte, error) {
+	type plain GroupField
+	return marshalJSONWithType("GroupField", (*plain)(f))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (o *Oneof) MarshalJSON() ([]byte, error) {
+	type plain Oneof
+	return marshalJSONWithType("Oneof", (*plain)(o))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f *OneofField) MarshalJSON() ([]byte, error) {
+	type plain OneofField
+	return marshalJSONWithType("OneofField", &struct {
+		FieldType string
+		*plain
+		// Type hides the one of plain, being shallower and omitted.
+		Type string `json:",omitempty"`
+	}{
+		FieldType: f.Type,
+		plain:     (*plain)(f),
+	})
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (r *Reserved) MarshalJSON() ([]byte, error) {
+	type plain Reserved
+	return marshalJSONWithType("Reserved", (*plain)(r))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e *Extensions) MarshalJSON() ([]byte, error) {
+	type plain Extensions
+	return marshalJSONWithType("Extensions", (*plain)(e))
+}
+
+// marshalJSONWithType marshals v, which must be marshaled into an object, and puts the type discriminator first.
+func marshalJSONWithType(typeName string, v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	prefix := fmt.Sprintf(`{"type":%q`, typeName)
+	if string(b) == "{}" {
+		return []byte(prefix + "}"), nil
+	}
+	return append([]byte(prefix+","), b[1:]...), nil
+}
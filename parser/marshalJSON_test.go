@@ -0,0 +1,149 @@
+package parser_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestMessage_MarshalJSON(t *testing.T) {
+	input := `message Foo {
+  int32 a = 1;
+  ;
+}`
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+	msg, err := p.ParseMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"type":"Message","MessageName":"Foo","MessageBody":[` +
+		`{"type":"Field","FieldType":"int32","IsRepeated":false,"IsRequired":false,"IsOptional":false,` +
+		`"FieldName":"a","FieldNumber":"1","FieldOptions":null,"Comments":null,"InlineComment":null,` +
+		`"Meta":{"Pos":{"Filename":"","Offset":16,"Line":2,"Column":3},"LastPos":{"Filename":"","Offset":27,"Line":2,"Column":14}}},` +
+		`{"type":"EmptyStatement","InlineComment":null,` +
+		`"Meta":{"Pos":{"Filename":"","Offset":31,"Line":3,"Column":3},"LastPos":{"Filename":"","Offset":31,"Line":3,"Column":3}}}],` +
+		`"Comments":null,"InlineComment":null,"InlineCommentBehindLeftCurly":null,` +
+		`"Meta":{"Pos":{"Filename":"","Offset":0,"Line":1,"Column":1},"LastPos":{"Filename":"","Offset":33,"Line":4,"Column":1}}}`
+	if string(got) != want {
+		t.Errorf("got %s, but want %s", got, want)
+	}
+}
+
+func TestProto_MarshalJSON(t *testing.T) {
+	input := `syntax = "proto3";
+package foo;
+import "other.proto";
+option java_package = "com.example.foo";
+message Outer {
+  reserved 2;
+  map<string, Inner> m = 1;
+  oneof o {
+    string s = 3;
+  }
+  message Inner {}
+}
+enum E {
+  A = 0;
+}
+service S {
+  rpc Get(Outer) returns (Outer);
+}
+`
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+	proto, err := p.ParseProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(proto)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var types []string
+	var collect func(raw json.RawMessage)
+	collect = func(raw json.RawMessage) {
+		var n map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &n); err != nil {
+			t.Fatal(err)
+		}
+		var typeName string
+		if err := json.Unmarshal(n["type"], &typeName); err != nil {
+			t.Fatal(err)
+		}
+		types = append(types, typeName)
+
+		if syntax, ok := n["Syntax"]; ok {
+			collect(syntax)
+		}
+		for _, key := range []string{"ProtoBody", "MessageBody", "EnumBody", "ServiceBody", "OneofFields"} {
+			var bodies []json.RawMessage
+			if err := json.Unmarshal(n[key], &bodies); err != nil {
+				continue
+			}
+			for _, body := range bodies {
+				collect(body)
+			}
+		}
+	}
+	collect(b)
+
+	want := "Proto Syntax Package Import Option Message Reserved MapField Oneof OneofField Message Enum EnumField Service RPC"
+	if got := strings.Join(types, " "); got != want {
+		t.Errorf("got %s, but want %s", got, want)
+	}
+}
+
+func TestField_MarshalJSON_decode(t *testing.T) {
+	input := `message Foo {
+  int32 a = 1;
+  map<string, Bar> m = 2;
+  oneof o {
+    string s = 3;
+  }
+}`
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+	msg, err := p.ParseMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type node struct {
+		Type        string
+		FieldType   string
+		KeyType     string
+		ValueType   string
+		OneofFields []node
+	}
+	var decoded struct {
+		MessageBody []node
+	}
+	err = json.Unmarshal(b, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []node{
+		{Type: "Field", FieldType: "int32"},
+		{Type: "MapField", KeyType: "string", ValueType: "Bar"},
+		{Type: "Oneof", OneofFields: []node{{Type: "OneofField", FieldType: "string"}}},
+	}
+	if !reflect.DeepEqual(decoded.MessageBody, want) {
+		t.Errorf("got %+v, but want %+v", decoded.MessageBody, want)
+	}
+}
@@ -1,8 +1,6 @@
 package parser
 
 import (
-	"fmt"
-
 	"github.com/yoheimuta/go-protoparser/v4/internal/lexer/scanner"
 	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
 )
@@ -12,12 +10,14 @@ type parseMessageBodyStatementErr struct {
 	parseEmptyStatementErr error
 }
 
+// Error reports the error of parsing the statement as a field, which is more informative than the one as an emptyStatement.
 func (e *parseMessageBodyStatementErr) Error() string {
-	return fmt.Sprintf(
-		"%v:%v",
-		e.parseFieldErr,
-		e.parseEmptyStatementErr,
-	)
+	return e.parseFieldErr.Error()
+}
+
+// Unwrap returns the error of parsing the statement as a field.
+func (e *parseMessageBodyStatementErr) Unwrap() error {
+	return e.parseFieldErr
 }
 
 // Message consists of a message name and a message body.
@@ -136,6 +136,14 @@ func (p *Parser) parseMessageBody() (
 		token := p.lex.Token
 		p.lex.UnNext()
 
+		// map is a keyword only when "<" follows. Otherwise, it is the type of an ordinary field.
+		if token == scanner.TMAP && p.lex.PeekN(2) != scanner.TLESS {
+			token = scanner.TIDENT
+		}
+		if token == scanner.TOPTION && !p.peekIsOption() {
+			token = scanner.TIDENT
+		}
+
 		var stmt interface {
 			HasInlineCommentSetter
 			Visitee
@@ -240,7 +248,7 @@ func (p *Parser) parseMessageBody() (
 
 			emptyErr := p.lex.ReadEmptyStatement()
 			if emptyErr == nil {
-				stmt = &EmptyStatement{}
+				stmt = newEmptyStatement(p.lex.Pos)
 				break
 			}
 
@@ -47,6 +47,11 @@ message Outer {
 								Line:   3,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 46,
+								Line:   3,
+								Column: 30,
+							},
 						},
 					},
 					&parser.Message{
@@ -62,6 +67,11 @@ message Outer {
 										Line:   5,
 										Column: 5,
 									},
+									LastPos: meta.Position{
+										Offset: 84,
+										Line:   5,
+										Column: 19,
+									},
 								},
 							},
 						},
@@ -89,6 +99,11 @@ message Outer {
 								Line:   7,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 121,
+								Line:   7,
+								Column: 32,
+							},
 						},
 					},
 				},
@@ -131,6 +146,11 @@ message outer {
 								Line:   3,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 46,
+								Line:   3,
+								Column: 30,
+							},
 						},
 					},
 					&parser.Message{
@@ -146,6 +166,11 @@ message outer {
 										Line:   5,
 										Column: 5,
 									},
+									LastPos: meta.Position{
+										Offset: 84,
+										Line:   5,
+										Column: 19,
+									},
 								},
 							},
 						},
@@ -173,6 +198,11 @@ message outer {
 								Line:   7,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 124,
+								Line:   7,
+								Column: 35,
+							},
 						},
 					},
 					&parser.Field{
@@ -185,6 +215,11 @@ message outer {
 								Line:   8,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 159,
+								Line:   8,
+								Column: 34,
+							},
 						},
 					},
 					&parser.MapField{
@@ -198,6 +233,11 @@ message outer {
 								Line:   9,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 192,
+								Line:   9,
+								Column: 32,
+							},
 						},
 					},
 				},
@@ -280,6 +320,11 @@ message outer {
 										Line:   3,
 										Column: 3,
 									},
+									LastPos: meta.Position{
+										Offset: 27,
+										Line:   3,
+										Column: 11,
+									},
 								},
 							},
 						},
@@ -289,6 +334,11 @@ message outer {
 								Line:   4,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 58,
+								Line:   4,
+								Column: 30,
+							},
 						},
 					},
 					&parser.Message{
@@ -304,6 +354,11 @@ message outer {
 										Line:   7,
 										Column: 5,
 									},
+									LastPos: meta.Position{
+										Offset: 122,
+										Line:   7,
+										Column: 19,
+									},
 								},
 							},
 						},
@@ -316,6 +371,11 @@ message outer {
 										Line:   5,
 										Column: 3,
 									},
+									LastPos: meta.Position{
+										Offset: 71,
+										Line:   5,
+										Column: 12,
+									},
 								},
 							},
 						},
@@ -327,6 +387,11 @@ message outer {
 									Line:   6,
 									Column: 21,
 								},
+								LastPos: meta.Position{
+									Offset: 102,
+									Line:   6,
+									Column: 30,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -356,6 +421,11 @@ message outer {
 										Line:   9,
 										Column: 3,
 									},
+									LastPos: meta.Position{
+										Offset: 137,
+										Line:   9,
+										Column: 10,
+									},
 								},
 							},
 						},
@@ -365,6 +435,11 @@ message outer {
 								Line:   10,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 173,
+								Line:   10,
+								Column: 35,
+							},
 						},
 					},
 					&parser.Enum{
@@ -379,6 +454,11 @@ message outer {
 										Line:   13,
 										Column: 5,
 									},
+									LastPos: meta.Position{
+										Offset: 241,
+										Line:   13,
+										Column: 30,
+									},
 								},
 							},
 						},
@@ -391,6 +471,11 @@ message outer {
 										Line:   11,
 										Column: 3,
 									},
+									LastPos: meta.Position{
+										Offset: 183,
+										Line:   11,
+										Column: 9,
+									},
 								},
 							},
 						},
@@ -417,6 +502,11 @@ message outer {
 								Line:   15,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 280,
+								Line:   15,
+								Column: 34,
+							},
 						},
 					},
 					&parser.MapField{
@@ -433,6 +523,11 @@ message outer {
 										Line:   16,
 										Column: 3,
 									},
+									LastPos: meta.Position{
+										Offset: 289,
+										Line:   16,
+										Column: 8,
+									},
 								},
 							},
 						},
@@ -442,6 +537,11 @@ message outer {
 								Line:   17,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 322,
+								Line:   17,
+								Column: 32,
+							},
 						},
 					},
 					&parser.Oneof{
@@ -456,6 +556,11 @@ message outer {
 										Line:   20,
 										Column: 5,
 									},
+									LastPos: meta.Position{
+										Offset: 368,
+										Line:   20,
+										Column: 20,
+									},
 								},
 							},
 							{
@@ -468,6 +573,11 @@ message outer {
 										Line:   21,
 										Column: 5,
 									},
+									LastPos: meta.Position{
+										Offset: 400,
+										Line:   21,
+										Column: 31,
+									},
 								},
 							},
 						},
@@ -481,6 +591,11 @@ message outer {
 										Line:   18,
 										Column: 3,
 									},
+									LastPos: meta.Position{
+										Offset: 333,
+										Line:   18,
+										Column: 10,
+									},
 								},
 							},
 						},
@@ -510,6 +625,11 @@ message outer {
 										Line:   23,
 										Column: 3,
 									},
+									LastPos: meta.Position{
+										Offset: 418,
+										Line:   23,
+										Column: 13,
+									},
 								},
 							},
 						},
@@ -519,6 +639,11 @@ message outer {
 								Line:   24,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 436,
+								Line:   24,
+								Column: 17,
+							},
 						},
 					},
 				},
@@ -561,6 +686,11 @@ message SearchRequest {
 								Line:   3,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 43,
+								Line:   3,
+								Column: 19,
+							},
 						},
 					},
 					&parser.Field{
@@ -575,6 +705,11 @@ message SearchRequest {
 									Line:   4,
 									Column: 27,
 								},
+								LastPos: meta.Position{
+									Offset: 102,
+									Line:   4,
+									Column: 58,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -583,6 +718,11 @@ message SearchRequest {
 								Line:   4,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 68,
+								Line:   4,
+								Column: 24,
+							},
 						},
 					},
 					&parser.Field{
@@ -597,6 +737,11 @@ message SearchRequest {
 									Line:   5,
 									Column: 31,
 								},
+								LastPos: meta.Position{
+									Offset: 173,
+									Line:   5,
+									Column: 70,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -605,6 +750,11 @@ message SearchRequest {
 								Line:   5,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 131,
+								Line:   5,
+								Column: 28,
+							},
 						},
 					},
 					&parser.Enum{
@@ -619,6 +769,11 @@ message SearchRequest {
 										Line:   7,
 										Column: 5,
 									},
+									LastPos: meta.Position{
+										Offset: 231,
+										Line:   7,
+										Column: 30,
+									},
 								},
 							},
 						},
@@ -630,6 +785,11 @@ message SearchRequest {
 									Line:   8,
 									Column: 5,
 								},
+								LastPos: meta.Position{
+									Offset: 244,
+									Line:   8,
+									Column: 12,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -681,6 +841,11 @@ message SearchRequest {
 								Line:   3,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 43,
+								Line:   3,
+								Column: 19,
+							},
 						},
 					},
 				},
@@ -721,6 +886,11 @@ message SearchRequest {
 								Line:   3,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 43,
+								Line:   3,
+								Column: 19,
+							},
 						},
 					},
 					&parser.Comment{
@@ -731,6 +901,11 @@ message SearchRequest {
 								Line:   4,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 67,
+								Line:   4,
+								Column: 23,
+							},
 						},
 					},
 					&parser.Comment{
@@ -741,6 +916,11 @@ message SearchRequest {
 								Line:   5,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 95,
+								Line:   5,
+								Column: 27,
+							},
 						},
 					},
 				},
@@ -783,6 +963,11 @@ message Outer {
 										Line:   4,
 										Column: 5,
 									},
+									LastPos: meta.Position{
+										Offset: 51,
+										Line:   4,
+										Column: 20,
+									},
 								},
 							},
 						},
@@ -837,6 +1022,11 @@ message Outer {
 								Line:   3,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 46,
+								Line:   3,
+								Column: 30,
+							},
 						},
 					},
 					&parser.Message{
@@ -853,6 +1043,11 @@ message Outer {
 										Line:   5,
 										Column: 5,
 									},
+									LastPos: meta.Position{
+										Offset: 106,
+										Line:   5,
+										Column: 28,
+									},
 								},
 							},
 						},
@@ -864,6 +1059,11 @@ message Outer {
 									Line:   4,
 									Column: 21,
 								},
+								LastPos: meta.Position{
+									Offset: 77,
+									Line:   4,
+									Column: 30,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -890,6 +1090,11 @@ message Outer {
 								Line:   7,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 143,
+								Line:   7,
+								Column: 32,
+							},
 						},
 					},
 					&parser.Extensions{
@@ -905,6 +1110,11 @@ message Outer {
 								Line:   8,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 166,
+								Line:   8,
+								Column: 22,
+							},
 						},
 					},
 				},
@@ -1004,6 +1214,489 @@ message Outer {
 				},
 			},
 		},
+		{
+			name: "parsing adjacent C-style comments followed by a C++-style comment",
+			input: `
+message outer {
+  /* block1 */ /* block2 */
+  /* block3
+   */
+  // line
+  int32 a = 1;
+}`,
+			wantMessage: &parser.Message{
+				MessageName: "outer",
+				MessageBody: []parser.Visitee{
+					&parser.Field{
+						Type:        "int32",
+						FieldName:   "a",
+						FieldNumber: "1",
+						Comments: []*parser.Comment{
+							{
+								Raw: `/* block1 */`,
+								Meta: meta.Meta{
+									Pos: meta.Position{
+										Offset: 19,
+										Line:   3,
+										Column: 3,
+									},
+									LastPos: meta.Position{
+										Offset: 30,
+										Line:   3,
+										Column: 14,
+									},
+								},
+							},
+							{
+								Raw: `/* block2 */`,
+								Meta: meta.Meta{
+									Pos: meta.Position{
+										Offset: 32,
+										Line:   3,
+										Column: 16,
+									},
+									LastPos: meta.Position{
+										Offset: 43,
+										Line:   3,
+										Column: 27,
+									},
+								},
+							},
+							{
+								Raw: `/* block3
+   */`,
+								Meta: meta.Meta{
+									Pos: meta.Position{
+										Offset: 47,
+										Line:   4,
+										Column: 3,
+									},
+									LastPos: meta.Position{
+										Offset: 61,
+										Line:   5,
+										Column: 5,
+									},
+								},
+							},
+							{
+								Raw: `// line`,
+								Meta: meta.Meta{
+									Pos: meta.Position{
+										Offset: 65,
+										Line:   6,
+										Column: 3,
+									},
+									LastPos: meta.Position{
+										Offset: 71,
+										Line:   6,
+										Column: 9,
+									},
+								},
+							},
+						},
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 75,
+								Line:   7,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 86,
+								Line:   7,
+								Column: 14,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 1,
+						Line:   2,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 88,
+						Line:   8,
+						Column: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "not parsing commented-out declarations",
+			input: `
+message outer {
+  // option deprecated = true;
+  // message Inner { int32 b = 2; }
+  /* int32 c = 3;
+  enum E { X = 0; } */
+  int32 a = 1; // int32 d = 4;
+}`,
+			wantMessage: &parser.Message{
+				MessageName: "outer",
+				MessageBody: []parser.Visitee{
+					&parser.Field{
+						Type:        "int32",
+						FieldName:   "a",
+						FieldNumber: "1",
+						Comments: []*parser.Comment{
+							{
+								Raw: `// option deprecated = true;`,
+								Meta: meta.Meta{
+									Pos: meta.Position{
+										Offset: 19,
+										Line:   3,
+										Column: 3,
+									},
+									LastPos: meta.Position{
+										Offset: 46,
+										Line:   3,
+										Column: 30,
+									},
+								},
+							},
+							{
+								Raw: `// message Inner { int32 b = 2; }`,
+								Meta: meta.Meta{
+									Pos: meta.Position{
+										Offset: 50,
+										Line:   4,
+										Column: 3,
+									},
+									LastPos: meta.Position{
+										Offset: 82,
+										Line:   4,
+										Column: 35,
+									},
+								},
+							},
+							{
+								Raw: `/* int32 c = 3;
+  enum E { X = 0; } */`,
+								Meta: meta.Meta{
+									Pos: meta.Position{
+										Offset: 86,
+										Line:   5,
+										Column: 3,
+									},
+									LastPos: meta.Position{
+										Offset: 123,
+										Line:   6,
+										Column: 22,
+									},
+								},
+							},
+						},
+						InlineComment: &parser.Comment{
+							Raw: `// int32 d = 4;`,
+							Meta: meta.Meta{
+								Pos: meta.Position{
+									Offset: 140,
+									Line:   7,
+									Column: 16,
+								},
+								LastPos: meta.Position{
+									Offset: 154,
+									Line:   7,
+									Column: 30,
+								},
+							},
+						},
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 127,
+								Line:   7,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 138,
+								Line:   7,
+								Column: 14,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 1,
+						Line:   2,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 156,
+						Line:   8,
+						Column: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "parsing map used as a field name and a message type",
+			input: `
+message outer {
+  int32 map = 1;
+  map other = 2;
+  map<string, map> m = 3;
+  repeated map maps = 4;
+}`,
+			wantMessage: &parser.Message{
+				MessageName: "outer",
+				MessageBody: []parser.Visitee{
+					&parser.Field{
+						Type:        "int32",
+						FieldName:   "map",
+						FieldNumber: "1",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 19,
+								Line:   3,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 32,
+								Line:   3,
+								Column: 16,
+							},
+						},
+					},
+					&parser.Field{
+						Type:        "map",
+						FieldName:   "other",
+						FieldNumber: "2",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 36,
+								Line:   4,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 49,
+								Line:   4,
+								Column: 16,
+							},
+						},
+					},
+					&parser.MapField{
+						KeyType:     "string",
+						Type:        "map",
+						MapName:     "m",
+						FieldNumber: "3",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 53,
+								Line:   5,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 75,
+								Line:   5,
+								Column: 25,
+							},
+						},
+					},
+					&parser.Field{
+						IsRepeated:  true,
+						Type:        "map",
+						FieldName:   "maps",
+						FieldNumber: "4",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 79,
+								Line:   6,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 100,
+								Line:   6,
+								Column: 24,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 1,
+						Line:   2,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 102,
+						Line:   7,
+						Column: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "parsing option used as a field name and a message type",
+			input: `
+message outer {
+  option (my_option).a = true;
+  option deprecated = true;
+  int32 option = 5;
+  option.Nested value = 6;
+}`,
+			wantMessage: &parser.Message{
+				MessageName: "outer",
+				MessageBody: []parser.Visitee{
+					&parser.Option{
+						OptionName: "(my_option).a",
+						Constant:   "true",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 19,
+								Line:   3,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 46,
+								Line:   3,
+								Column: 30,
+							},
+						},
+					},
+					&parser.Option{
+						OptionName: "deprecated",
+						Constant:   "true",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 50,
+								Line:   4,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 74,
+								Line:   4,
+								Column: 27,
+							},
+						},
+					},
+					&parser.Field{
+						Type:        "int32",
+						FieldName:   "option",
+						FieldNumber: "5",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 78,
+								Line:   5,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 94,
+								Line:   5,
+								Column: 19,
+							},
+						},
+					},
+					&parser.Field{
+						Type:        "option.Nested",
+						FieldName:   "value",
+						FieldNumber: "6",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 98,
+								Line:   6,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 121,
+								Line:   6,
+								Column: 26,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 1,
+						Line:   2,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 123,
+						Line:   7,
+						Column: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "parsing consecutive options sharing a parenthesized prefix",
+			input: `
+message outer {
+  option (a) = {
+    b: 1
+  };
+  option (a).b = 2;
+  option (a).c.d = "e";
+}`,
+			permissive: true,
+			wantMessage: &parser.Message{
+				MessageName: "outer",
+				MessageBody: []parser.Visitee{
+					&parser.Option{
+						OptionName: "(a)",
+						Constant:   "{b:1}",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 19,
+								Line:   3,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 46,
+								Line:   5,
+								Column: 4,
+							},
+						},
+					},
+					&parser.Option{
+						OptionName: "(a).b",
+						Constant:   "2",
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 50,
+								Line:   6,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 66,
+								Line:   6,
+								Column: 19,
+							},
+						},
+					},
+					&parser.Option{
+						OptionName: "(a).c.d",
+						Constant:   `"e"`,
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 70,
+								Line:   7,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 90,
+								Line:   7,
+								Column: 23,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 1,
+						Line:   2,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 92,
+						Line:   8,
+						Column: 1,
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
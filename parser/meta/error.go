@@ -14,14 +14,27 @@ type Error struct {
 	occuredAt int
 }
 
+// Error reports the expected and the found tokens with the position, like `expected [{], found "int32" at foo.proto:3:8`.
+// An empty Found is reported as EOF.
 func (e *Error) Error() string {
+	msg := fmt.Sprintf("%s at %s", e.Message(), e.Pos)
 	if e.occuredAt == 0 && e.occuredIn == "" {
-		return fmt.Sprintf("found %q but expected [%s]", e.Found, e.Expected)
+		return msg
 	}
-	return fmt.Sprintf("found %q but expected [%s] at %s:%d", e.Found, e.Expected, e.occuredIn, e.occuredAt)
+	return fmt.Sprintf("%s (raised at %s:%d)", msg, e.occuredIn, e.occuredAt)
+}
+
+// Message reports the expected and the found tokens without the position, like `expected [{], found "int32"`.
+func (e *Error) Message() string {
+	found := "EOF"
+	if e.Found != "" {
+		found = fmt.Sprintf("%q", e.Found)
+	}
+	return fmt.Sprintf("expected [%s], found %s", e.Expected, found)
 }
 
 // SetOccured sets the file and the line number at which the error was raised (through runtime.Caller).
+// They are reported for debugging.
 func (e *Error) SetOccured(occuredIn string, occuredAt int) {
 	e.occuredIn = occuredIn
 	e.occuredAt = occuredAt
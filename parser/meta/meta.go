@@ -5,6 +5,24 @@ type Meta struct {
 	// Pos is the source position.
 	Pos Position
 	// LastPos is the last source position.
-	// Currently it is set when the parsed element type is message, enum, oneof, rpc or service.
+	// It is the position of the token closing the element, such as "}", ";" or ")".
+	// For a comment, it is the position of the last character, whose Offset is the one of its last byte.
 	LastPos Position
+	// Raw is the verbatim source text from Pos to LastPos.
+	// It is set only when the parser is configured with WithRawBody.
+	Raw string `json:",omitempty"`
+}
+
+// ByteRange returns the span of the element in the source as the byte offsets [start, end),
+// so that source[start:end] is the text of the element.
+// The offsets count bytes, not runes, so a multi-byte UTF-8 character like "é" advances them by its encoded length
+// while the Column advances by one. The source is assumed to be valid UTF-8.
+//
+// The end is right after the last byte at LastPos.
+// When LastPos is unknown, as in FieldOption, the range is empty at Pos.
+func (m *Meta) ByteRange() (start int, end int) {
+	if m.LastPos.Line == 0 {
+		return m.Pos.Offset, m.Pos.Offset
+	}
+	return m.Pos.Offset, m.LastPos.Offset + 1
 }
@@ -0,0 +1,117 @@
+package meta_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
+)
+
+func TestMeta_ByteRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputMeta meta.Meta
+		wantStart int
+		wantEnd   int
+	}{
+		{
+			name: "meta with LastPos",
+			inputMeta: meta.Meta{
+				Pos: meta.Position{
+					Offset: 3,
+					Line:   1,
+					Column: 4,
+				},
+				LastPos: meta.Position{
+					Offset: 10,
+					Line:   2,
+					Column: 1,
+				},
+			},
+			wantStart: 3,
+			wantEnd:   11,
+		},
+		{
+			name: "meta without LastPos",
+			inputMeta: meta.Meta{
+				Pos: meta.Position{
+					Offset: 3,
+					Line:   1,
+					Column: 4,
+				},
+			},
+			wantStart: 3,
+			wantEnd:   3,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			start, end := test.inputMeta.ByteRange()
+			if start != test.wantStart || end != test.wantEnd {
+				t.Errorf("got (%d, %d), but want (%d, %d)", start, end, test.wantStart, test.wantEnd)
+			}
+		})
+	}
+}
+
+func TestMeta_ByteRange_slicingSource(t *testing.T) {
+	source := `syntax = "proto3";
+// Ünïcode 日本
+message Cafe {
+  string s = 1 [json_name = "日本"];
+  enum E { E_A = 0; }
+}
+`
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(source)))
+	proto, err := p.ParseProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := proto.ProtoBody[0].(*parser.Message)
+	field := message.MessageBody[0].(*parser.Field)
+	enum := message.MessageBody[1].(*parser.Enum)
+
+	tests := []struct {
+		name     string
+		meta     meta.Meta
+		wantText string
+	}{
+		{
+			name: "slicing a message",
+			meta: message.Meta,
+			wantText: `message Cafe {
+  string s = 1 [json_name = "日本"];
+  enum E { E_A = 0; }
+}`,
+		},
+		{
+			name:     "slicing a field after multi-byte characters",
+			meta:     field.Meta,
+			wantText: `string s = 1 [json_name = "日本"];`,
+		},
+		{
+			name:     "slicing an enum",
+			meta:     enum.Meta,
+			wantText: `enum E { E_A = 0; }`,
+		},
+		{
+			name:     "slicing a comment ending with a multi-byte character",
+			meta:     message.Comments[0].Meta,
+			wantText: "// Ünïcode 日本",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			start, end := test.meta.ByteRange()
+			if got := source[start:end]; got != test.wantText {
+				t.Errorf("got %q, but want %q", got, test.wantText)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
+)
+
+// MigrationWarning is a warning about a construct which has to be changed, like a proto2 construct to migrate to proto3
+// or a non-canonical spelling of a bool constant.
+type MigrationWarning struct {
+	// Pos is the source position of the construct.
+	Pos meta.Position
+	// Message describes the construct.
+	Message string
+	// Suggestion describes the proto3 equivalent.
+	Suggestion string
+}
+
+func (w *MigrationWarning) String() string {
+	return fmt.Sprintf("%s: %s; %s", w.Pos, w.Message, w.Suggestion)
+}
+
+// Warnings returns the warnings collected by the last ParseProto.
+// The warnings about the proto2 constructs are collected when the parser is configured with WithMigrationWarnings,
+// and the ones about the bool constants spelled like "True" or "1" are collected unless the parser is permissive.
+func (p *Parser) Warnings() []*MigrationWarning {
+	return p.warnings
+}
+
+// migrationWarnings returns the warnings about required fields, groups, extensions and explicit defaults in the proto2.
+func migrationWarnings(proto *Proto) []*MigrationWarning {
+	if proto.SyntaxVersion() != "proto2" {
+		return nil
+	}
+
+	var warnings []*MigrationWarning
+	for _, body := range proto.ProtoBody {
+		if msg, ok := body.(*Message); ok {
+			warnings = append(warnings, migrationWarningsOfMessageBody(msg.MessageBody)...)
+		}
+	}
+	return warnings
+}
+
+func migrationWarningsOfMessageBody(body []Visitee) []*MigrationWarning {
+	var warnings []*MigrationWarning
+	for _, element := range body {
+		switch e := element.(type) {
+		case *Field:
+			if e.IsRequired {
+				warnings = append(warnings, &MigrationWarning{
+					Pos:        e.Meta.Pos,
+					Message:    fmt.Sprintf("field %q is required", e.FieldName),
+					Suggestion: "remove the required label and validate the presence in the application",
+				})
+			}
+			warnings = append(warnings, migrationWarningsOfFieldOptions(e.FieldName, e.FieldOptions, e.Meta.Pos)...)
+		case *OneofField:
+			warnings = append(warnings, migrationWarningsOfFieldOptions(e.FieldName, e.FieldOptions, e.Meta.Pos)...)
+		case *Oneof:
+			for _, field := range e.OneofFields {
+				warnings = append(warnings, migrationWarningsOfMessageBody([]Visitee{field})...)
+			}
+		case *GroupField:
+			warnings = append(warnings, &MigrationWarning{
+				Pos:        e.Meta.Pos,
+				Message:    fmt.Sprintf("group %q is used", e.GroupName),
+				Suggestion: "define a nested message and a field of its type",
+			})
+			warnings = append(warnings, migrationWarningsOfMessageBody(e.MessageBody)...)
+		case *Extensions:
+			warnings = append(warnings, &MigrationWarning{
+				Pos:        e.Meta.Pos,
+				Message:    "extensions are declared",
+				Suggestion: "use a google.protobuf.Any field",
+			})
+		case *Message:
+			warnings = append(warnings, migrationWarningsOfMessageBody(e.MessageBody)...)
+		}
+	}
+	return warnings
+}
+
+func migrationWarningsOfFieldOptions(fieldName string, options []*FieldOption, pos meta.Position) []*MigrationWarning {
+	if !hasDefaultFieldOption(options) {
+		return nil
+	}
+	return []*MigrationWarning{
+		{
+			Pos:        pos,
+			Message:    fmt.Sprintf("field %q has an explicit default", fieldName),
+			Suggestion: "remove the default and rely on the zero value, or use the optional label to detect the presence",
+		},
+	}
+}
@@ -0,0 +1,120 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestParser_Warnings(t *testing.T) {
+	tests := []struct {
+		name                   string
+		input                  string
+		inputMigrationWarnings bool
+		inputPermissive        bool
+		wantWarnings           []string
+	}{
+		{
+			name: "parsing a proto2 file",
+			input: `syntax = "proto2";
+message SearchResponse {
+  required string query = 1;
+  optional int32 page = 2 [default = 1];
+  repeated group Result = 3 {
+    required string url = 4;
+  }
+  oneof test_oneof {
+    string name = 5 [default = "a"];
+  }
+  extensions 100 to 199;
+}
+`,
+			inputMigrationWarnings: true,
+			wantWarnings: []string{
+				`<input>:3:3: field "query" is required; remove the required label and validate the presence in the application`,
+				`<input>:4:3: field "page" has an explicit default; remove the default and rely on the zero value, or use the optional label to detect the presence`,
+				`<input>:5:3: group "Result" is used; define a nested message and a field of its type`,
+				`<input>:6:5: field "url" is required; remove the required label and validate the presence in the application`,
+				`<input>:9:5: field "name" has an explicit default; remove the default and rely on the zero value, or use the optional label to detect the presence`,
+				`<input>:11:3: extensions are declared; use a google.protobuf.Any field`,
+			},
+		},
+		{
+			name: "parsing a proto2 file without the option",
+			input: `syntax = "proto2";
+message SearchResponse {
+  required string query = 1;
+}
+`,
+		},
+		{
+			name: "parsing a proto3 file",
+			input: `syntax = "proto3";
+message SearchResponse {
+  string query = 1;
+}
+`,
+			inputMigrationWarnings: true,
+		},
+		{
+			name: "parsing the bool constants with the non-canonical spellings",
+			input: `syntax = "proto3";
+option java_multiple_files = True;
+option (my_int) = 1;
+option (my_bool) = False;
+message Foo {
+  string a = 1 [deprecated = 1, packed = true];
+  enum E {
+    E_A = 0 [deprecated = True];
+  }
+}
+service S {
+  rpc Get(Foo) returns (Foo) {
+    option deprecated = 0;
+  }
+}
+`,
+			wantWarnings: []string{
+				`<input>:2:1: option "java_multiple_files" spells the bool as "True"; use true`,
+				`<input>:4:1: option "(my_bool)" spells the bool as "False"; use false`,
+				`<input>:6:17: option "deprecated" spells the bool as "1"; use true`,
+				`<input>:8:5: option "deprecated" spells the bool as "True"; use true`,
+				`<input>:13:5: option "deprecated" spells the bool as "0"; use false`,
+			},
+		},
+		{
+			name: "parsing the bool constants with the non-canonical spellings by permissive mode",
+			input: `syntax = "proto3";
+option java_multiple_files = True;
+`,
+			inputPermissive: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(
+				lexer.NewLexer(strings.NewReader(test.input)),
+				parser.WithMigrationWarnings(test.inputMigrationWarnings),
+				parser.WithPermissive(test.inputPermissive),
+			)
+			_, err := p.ParseProto()
+			if err != nil {
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			var got []string
+			for _, w := range p.Warnings() {
+				got = append(got, w.String())
+			}
+			if !reflect.DeepEqual(got, test.wantWarnings) {
+				t.Errorf("got %v, but want %v", got, test.wantWarnings)
+			}
+		})
+	}
+}
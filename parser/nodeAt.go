@@ -0,0 +1,130 @@
+package parser
+
+import "github.com/yoheimuta/go-protoparser/v4/parser/meta"
+
+// NodeAt returns the innermost node whose span contains the given line and column.
+// It returns nil when no node contains the position, such as within the comments placed before a declaration.
+//
+// The span of a node which has LastPos ends at LastPos.
+// Otherwise, it ends before the next declaration, including its comments, or the end of the enclosing node.
+func (p *Proto) NodeAt(pos meta.Position) interface{} {
+	var nodes []Visitee
+	if p.Syntax != nil {
+		nodes = append(nodes, p.Syntax)
+	}
+	if p.Edition != nil {
+		nodes = append(nodes, p.Edition)
+	}
+	nodes = append(nodes, p.ProtoBody...)
+	return nodeAt(nodes, meta.Position{}, pos)
+}
+
+// nodeAt returns the innermost node among the nodes whose span contains the position.
+// bound is the exclusive end of the enclosing node, and the zero value means it's unbounded.
+func nodeAt(nodes []Visitee, bound meta.Position, pos meta.Position) interface{} {
+	for i, node := range nodes {
+		span, ok := spanOf(node)
+		if !ok {
+			continue
+		}
+
+		var contained bool
+		childBound := span.meta.LastPos
+		if span.meta.LastPos.Line == 0 {
+			childBound = nextDeclarationStart(nodes[i+1:], bound)
+			contained = !isBefore(pos, span.meta.Pos) &&
+				(childBound.Line == 0 || isBefore(pos, childBound))
+		} else {
+			contained = !isBefore(pos, span.meta.Pos) && !isBefore(span.meta.LastPos, pos)
+		}
+		if !contained {
+			continue
+		}
+
+		if child := nodeAt(span.children, childBound, pos); child != nil {
+			return child
+		}
+		return node
+	}
+	return nil
+}
+
+// nextDeclarationStart returns where the first declaration among the nodes begins, including its comments.
+// It returns bound when there is no declaration.
+func nextDeclarationStart(nodes []Visitee, bound meta.Position) meta.Position {
+	for _, node := range nodes {
+		span, ok := spanOf(node)
+		if !ok {
+			continue
+		}
+		if 0 < len(span.comments) {
+			return span.comments[0].Meta.Pos
+		}
+		return span.meta.Pos
+	}
+	return bound
+}
+
+// isBefore reports whether a is placed before b in terms of the line and the column.
+func isBefore(a, b meta.Position) bool {
+	if a.Line != b.Line {
+		return a.Line < b.Line
+	}
+	return a.Column < b.Column
+}
+
+type nodeSpan struct {
+	meta     meta.Meta
+	comments []*Comment
+	children []Visitee
+}
+
+// spanOf returns the span of a node. It returns false when the node has no position, such as a comment.
+func spanOf(node Visitee) (nodeSpan, bool) {
+	switch n := node.(type) {
+	case *EmptyStatement:
+		return nodeSpan{meta: n.Meta}, true
+	case *Syntax:
+		return nodeSpan{meta: n.Meta, comments: n.Comments}, true
+	case *Edition:
+		return nodeSpan{meta: n.Meta, comments: n.Comments}, true
+	case *Import:
+		return nodeSpan{meta: n.Meta, comments: n.Comments}, true
+	case *Package:
+		return nodeSpan{meta: n.Meta, comments: n.Comments}, true
+	case *Option:
+		return nodeSpan{meta: n.Meta, comments: n.Comments}, true
+	case *Message:
+		return nodeSpan{meta: n.Meta, comments: n.Comments, children: n.MessageBody}, true
+	case *Enum:
+		return nodeSpan{meta: n.Meta, comments: n.Comments, children: n.EnumBody}, true
+	case *EnumField:
+		return nodeSpan{meta: n.Meta, comments: n.Comments}, true
+	case *Service:
+		return nodeSpan{meta: n.Meta, comments: n.Comments, children: n.ServiceBody}, true
+	case *RPC:
+		var children []Visitee
+		for _, option := range n.Options {
+			children = append(children, option)
+		}
+		return nodeSpan{meta: n.Meta, comments: n.Comments, children: children}, true
+	case *Extend:
+		return nodeSpan{meta: n.Meta, comments: n.Comments, children: n.ExtendBody}, true
+	case *Field:
+		return nodeSpan{meta: n.Meta, comments: n.Comments}, true
+	case *MapField:
+		return nodeSpan{meta: n.Meta, comments: n.Comments}, true
+	case *GroupField:
+		return nodeSpan{meta: n.Meta, comments: n.Comments, children: n.MessageBody}, true
+	case *Oneof:
+		return nodeSpan{meta: n.Meta, comments: n.Comments, children: oneofBody(n)}, true
+	case *OneofField:
+		return nodeSpan{meta: n.Meta, comments: n.Comments}, true
+	case *Reserved:
+		return nodeSpan{meta: n.Meta, comments: n.Comments}, true
+	case *Extensions:
+		return nodeSpan{meta: n.Meta, comments: n.Comments}, true
+	default:
+		return nodeSpan{}, false
+	}
+}
@@ -0,0 +1,153 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
+)
+
+func TestProto_NodeAt(t *testing.T) {
+	input := `syntax = "proto3";
+// An example of the official reference
+// See https://developers.google.com/protocol-buffers/docs/reference/proto3-spec#proto_file
+package examplepb;
+import public "other.proto";
+option java_package = "com.example.foo";
+enum EnumAllowingAlias {
+    option allow_alias = true;
+    UNKNOWN = 0;
+    STARTED = 1;
+    RUNNING = 2 [(custom_option) = "this is a "
+                                   "string on two lines"
+                ];
+}
+message outer {
+    option (my_option).a = true;
+    message inner {   // Level 2
+      int64 ival = 1;
+    }
+    repeated inner inner_message = 2;
+    EnumAllowingAlias enum_field =3;
+    map<int32, string> my_map = 4;
+    oneof test_oneof {
+      string name = 5;
+      option (oneof_option) = true;
+    }
+}
+service HelloService {
+  rpc SayHello (HelloRequest) returns (HelloResponse) {};
+}
+`
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)), parser.WithPermissive(true))
+	proto, err := p.ParseProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enum := proto.ProtoBody[3].(*parser.Enum)
+	outer := proto.ProtoBody[4].(*parser.Message)
+	inner := outer.MessageBody[1].(*parser.Message)
+	oneof := outer.MessageBody[5].(*parser.Oneof)
+	service := proto.ProtoBody[5].(*parser.Service)
+
+	tests := []struct {
+		name      string
+		inputLine int
+		inputCol  int
+		wantNode  interface{}
+	}{
+		{
+			name:      "locating the syntax",
+			inputLine: 1,
+			inputCol:  5,
+			wantNode:  proto.Syntax,
+		},
+		{
+			name:      "locating the comments of the package",
+			inputLine: 2,
+			inputCol:  5,
+		},
+		{
+			name:      "locating a field in a nested message",
+			inputLine: 18,
+			inputCol:  13,
+			wantNode:  inner.MessageBody[0],
+		},
+		{
+			name:      "locating the field type of a field",
+			inputLine: 21,
+			inputCol:  5,
+			wantNode:  outer.MessageBody[3],
+		},
+		{
+			name:      "locating the inline comment of a nested message",
+			inputLine: 17,
+			inputCol:  25,
+			wantNode:  inner,
+		},
+		{
+			name:      "locating the end of a nested message",
+			inputLine: 19,
+			inputCol:  5,
+			wantNode:  inner,
+		},
+		{
+			name:      "locating the continued line of an enum value",
+			inputLine: 12,
+			inputCol:  40,
+			wantNode:  enum.EnumBody[3],
+		},
+		{
+			name:      "locating the end of an enum",
+			inputLine: 14,
+			inputCol:  1,
+			wantNode:  enum,
+		},
+		{
+			name:      "locating an option in a oneof",
+			inputLine: 25,
+			inputCol:  7,
+			wantNode:  oneof.Options[0],
+		},
+		{
+			name:      "locating a field in a oneof",
+			inputLine: 24,
+			inputCol:  14,
+			wantNode:  oneof.OneofFields[0],
+		},
+		{
+			name:      "locating a rpc",
+			inputLine: 29,
+			inputCol:  20,
+			wantNode:  service.ServiceBody[0],
+		},
+		{
+			name:      "locating the end of a message",
+			inputLine: 27,
+			inputCol:  1,
+			wantNode:  outer,
+		},
+		{
+			name:      "locating the end of a file",
+			inputLine: 31,
+			inputCol:  1,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got := proto.NodeAt(meta.Position{
+				Line:   test.inputLine,
+				Column: test.inputCol,
+			})
+			if !reflect.DeepEqual(got, test.wantNode) {
+				t.Errorf("got %#v, but want %#v", got, test.wantNode)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package parser
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Normalize returns a copy of the proto in a deterministic form which ignores the formatting,
+// so that deep-equaling two normalized protos detects only the semantic changes.
+//
+// The copy has no comments, empty statements, positions nor filename.
+// RawConstant and Multiline, which keep the spellings, are cleared as well.
+// The field numbers, the enum value numbers, the ranges, the import locations, the reserved names and
+// the constants of the options are spelled canonically, like `"a.b"` for `'a.b'`, `16` for `0x10` and `1.5` for `1.50`.
+// The declarations are reordered by SortDeclarations, and the options of each body and each option list are
+// ordered by name. The sort is stable, so the repeated options with the same name keep their order.
+//
+// The original proto is left untouched.
+func Normalize(proto *Proto) *Proto {
+	normalized := &Proto{
+		InferredSyntax: proto.InferredSyntax,
+		ProtoBody:      normalizeBody(proto.ProtoBody),
+		Meta:           &ProtoMeta{},
+	}
+	if proto.Syntax != nil {
+		normalized.Syntax = &Syntax{ProtobufVersion: proto.Syntax.ProtobufVersion}
+	}
+	if proto.Edition != nil {
+		normalized.Edition = &Edition{Edition: proto.Edition.Edition}
+	}
+	SortDeclarations(normalized)
+	return normalized
+}
+
+// normalizeBody copies the body without the comments and the empty statements.
+// The options are ordered by name among the places where the options are.
+func normalizeBody(body []Visitee) []Visitee {
+	var normalized []Visitee
+	var options []*Option
+	var optionIndexes []int
+	for _, element := range body {
+		n := normalizeElement(element)
+		if n == nil {
+			continue
+		}
+		if option, ok := n.(*Option); ok {
+			options = append(options, option)
+			optionIndexes = append(optionIndexes, len(normalized))
+		}
+		normalized = append(normalized, n)
+	}
+
+	sortOptions(options)
+	for i, index := range optionIndexes {
+		normalized[index] = options[i]
+	}
+	return normalized
+}
+
+// normalizeElement copies the element. It returns nil for the element which Normalize drops.
+func normalizeElement(element Visitee) Visitee {
+	switch e := element.(type) {
+	case *Import:
+		return &Import{
+			Modifier: e.Modifier,
+			Location: normalizeConstant(e.Location),
+		}
+	case *Package:
+		return &Package{
+			Name: e.Name,
+		}
+	case *Option:
+		return normalizeOption(e)
+	case *Message:
+		return &Message{
+			MessageName: e.MessageName,
+			MessageBody: normalizeBody(e.MessageBody),
+		}
+	case *Enum:
+		return &Enum{
+			EnumName: e.EnumName,
+			EnumBody: normalizeBody(e.EnumBody),
+		}
+	case *EnumField:
+		var options []*EnumValueOption
+		for _, option := range e.EnumValueOptions {
+			options = append(options, &EnumValueOption{
+				OptionName: option.OptionName,
+				Constant:   normalizeConstant(option.Constant),
+			})
+		}
+		sort.SliceStable(options, func(i, j int) bool {
+			return options[i].OptionName < options[j].OptionName
+		})
+		return &EnumField{
+			Ident:            e.Ident,
+			Number:           normalizeConstant(e.Number),
+			EnumValueOptions: options,
+		}
+	case *Service:
+		return &Service{
+			ServiceName: e.ServiceName,
+			ServiceBody: normalizeBody(e.ServiceBody),
+		}
+	case *RPC:
+		return &RPC{
+			RPCName: e.RPCName,
+			RPCRequest: &RPCRequest{
+				IsStream:    e.RPCRequest.IsStream,
+				MessageType: e.RPCRequest.MessageType,
+			},
+			RPCResponse: &RPCResponse{
+				IsStream:    e.RPCResponse.IsStream,
+				MessageType: e.RPCResponse.MessageType,
+			},
+			Options: normalizeOptions(e.Options),
+		}
+	case *Extend:
+		return &Extend{
+			MessageType: e.MessageType,
+			ExtendBody:  normalizeBody(e.ExtendBody),
+		}
+	case *Field:
+		return &Field{
+			IsRepeated:   e.IsRepeated,
+			IsRequired:   e.IsRequired,
+			IsOptional:   e.IsOptional,
+			Type:         e.Type,
+			FieldName:    e.FieldName,
+			FieldNumber:  normalizeConstant(e.FieldNumber),
+			FieldOptions: normalizeFieldOptions(e.FieldOptions),
+		}
+	case *MapField:
+		return &MapField{
+			KeyType:      e.KeyType,
+			Type:         e.Type,
+			MapName:      e.MapName,
+			FieldNumber:  normalizeConstant(e.FieldNumber),
+			FieldOptions: normalizeFieldOptions(e.FieldOptions),
+		}
+	case *GroupField:
+		return &GroupField{
+			IsRepeated:  e.IsRepeated,
+			IsRequired:  e.IsRequired,
+			IsOptional:  e.IsOptional,
+			GroupName:   e.GroupName,
+			MessageBody: normalizeBody(e.MessageBody),
+			FieldNumber: normalizeConstant(e.FieldNumber),
+		}
+	case *Oneof:
+		var fields []*OneofField
+		for _, field := range e.OneofFields {
+			fields = append(fields, &OneofField{
+				Type:         field.Type,
+				FieldName:    field.FieldName,
+				FieldNumber:  normalizeConstant(field.FieldNumber),
+				FieldOptions: normalizeFieldOptions(field.FieldOptions),
+			})
+		}
+		return &Oneof{
+			OneofFields: fields,
+			OneofName:   e.OneofName,
+			Options:     normalizeOptions(e.Options),
+		}
+	case *Reserved:
+		var fieldNames []string
+		for _, fieldName := range e.FieldNames {
+			fieldNames = append(fieldNames, normalizeConstant(fieldName))
+		}
+		return &Reserved{
+			Ranges:     normalizeRanges(e.Ranges),
+			FieldNames: fieldNames,
+		}
+	case *Extensions:
+		return &Extensions{
+			Ranges: normalizeRanges(e.Ranges),
+		}
+	default:
+		// *Comment and *EmptyStatement.
+		return nil
+	}
+}
+
+func normalizeOption(option *Option) *Option {
+	return &Option{
+		OptionName: option.OptionName,
+		Constant:   normalizeConstant(option.Constant),
+	}
+}
+
+func normalizeOptions(options []*Option) []*Option {
+	var normalized []*Option
+	for _, option := range options {
+		normalized = append(normalized, normalizeOption(option))
+	}
+	sortOptions(normalized)
+	return normalized
+}
+
+func normalizeFieldOptions(options []*FieldOption) []*FieldOption {
+	var normalized []*FieldOption
+	for _, option := range options {
+		normalized = append(normalized, &FieldOption{
+			OptionName: option.OptionName,
+			Constant:   normalizeConstant(option.Constant),
+		})
+	}
+	sort.SliceStable(normalized, func(i, j int) bool {
+		return normalized[i].OptionName < normalized[j].OptionName
+	})
+	return normalized
+}
+
+// normalizeConstant spells the constant canonically, so that the constants which differ only in the formatting are equal.
+// A string is requoted by '"', an integer is written in decimal, a float is written in the shortest form
+// keeping its decimal point or exponent, and an aggregate is rendered in a line with the fields ordered by name
+// and the scalars normalized. Any other constant, like an identifier, or a malformed one is kept as written.
+func normalizeConstant(constant string) string {
+	if strings.HasPrefix(constant, "{") {
+		structured, err := ParseOptionConstant(constant)
+		if err != nil {
+			return constant
+		}
+		return normalizeAggregate(structured.Fields)
+	}
+	if strings.HasPrefix(constant, `"`) || strings.HasPrefix(constant, "'") {
+		value, err := UnquoteStrLit(constant)
+		if err != nil {
+			return constant
+		}
+		return strconv.Quote(value)
+	}
+	if n, err := strconv.ParseInt(constant, 0, 64); err == nil {
+		return strconv.FormatInt(n, 10)
+	}
+	if n, err := strconv.ParseUint(constant, 0, 64); err == nil {
+		return strconv.FormatUint(n, 10)
+	}
+	if isFloatLit(constant) {
+		if f, err := strconv.ParseFloat(constant, 64); err == nil {
+			text := strconv.FormatFloat(f, 'g', -1, 64)
+			if !strings.ContainsAny(text, ".e") {
+				text += ".0"
+			}
+			return text
+		}
+	}
+	return constant
+}
+
+// isFloatLit reports whether the constant starts like a float literal, leaving out inf and nan,
+// which strconv.ParseFloat accepts but are identifiers in protobuf.
+func isFloatLit(constant string) bool {
+	digits := strings.TrimLeft(constant, "+-")
+	return digits != "" && (digits[0] == '.' || ('0' <= digits[0] && digits[0] <= '9'))
+}
+
+// normalizeAggregate renders the fields like `{a:1,b:{c:"d"},e:2,e:3}`. A list is rendered as the repeated fields,
+// which is equivalent, and an empty list as `e:[]`.
+func normalizeAggregate(fields map[string][]*OptionConstant) string {
+	var names []string
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var entries []string
+	for _, name := range names {
+		values := fields[name]
+		if len(values) == 0 {
+			entries = append(entries, name+":[]")
+			continue
+		}
+		for _, value := range values {
+			if value.IsAggregate() {
+				entries = append(entries, name+":"+normalizeAggregate(value.Fields))
+			} else {
+				entries = append(entries, name+":"+normalizeConstant(value.Scalar))
+			}
+		}
+	}
+	return "{" + strings.Join(entries, ",") + "}"
+}
+
+func normalizeRanges(ranges []*Range) []*Range {
+	var normalized []*Range
+	for _, r := range ranges {
+		normalized = append(normalized, &Range{
+			Begin: normalizeConstant(r.Begin),
+			End:   normalizeConstant(r.End),
+		})
+	}
+	return normalized
+}
+
+func sortOptions(options []*Option) {
+	sort.SliceStable(options, func(i, j int) bool {
+		return options[i].OptionName < options[j].OptionName
+	})
+}
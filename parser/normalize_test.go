@@ -0,0 +1,277 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		oldInput  string
+		newInput  string
+		wantEqual bool
+	}{
+		{
+			name: "normalizing the protos which differ only in the formatting",
+			oldInput: `syntax = "proto3";
+option java_package = "foo";
+option go_package = "foo";
+// Foo is a message.
+message Foo {
+  option deprecated = true;
+  option (bar) = 1;
+  string b = 2 [json_name = "B", deprecated = true];
+  int32 a = 1; // a
+  reserved 3 to 5, 7;
+  enum E {
+    E_B = 1;
+    E_A = 0;
+  }
+}
+`,
+			newInput: `syntax = "proto3";
+option go_package = "foo";;
+option java_package = "f" "oo";
+
+message Foo {
+  option (bar) = 1;
+  option deprecated = true;
+
+  reserved 3 to 5,
+    7;
+  int32 a = 1;
+  string b = 2 [deprecated = true, json_name = "B"];
+  enum E { E_A = 0; E_B = 1; }
+}
+`,
+			wantEqual: true,
+		},
+		{
+			name: "normalizing the protos which differ in a field type",
+			oldInput: `syntax = "proto3";
+message Foo {
+  int32 a = 1;
+}
+`,
+			newInput: `syntax = "proto3";
+message Foo {
+  int64 a = 1;
+}
+`,
+		},
+		{
+			name: "normalizing the protos which differ in the order of the repeated options",
+			oldInput: `syntax = "proto3";
+option (foo) = 1;
+option (foo) = 2;
+`,
+			newInput: `syntax = "proto3";
+option (foo) = 2;
+option (foo) = 1;
+`,
+		},
+		{
+			name: "normalizing the protos which differ in the quotes of the string constants",
+			oldInput: `syntax = "proto3";
+option java_package = 'a.b';
+message Foo {
+  string a = 1 [json_name = 'A\x41'];
+}
+`,
+			newInput: `syntax = "proto3";
+option java_package = "a.b";
+message Foo {
+  string a = 1 [json_name = "AA"];
+}
+`,
+			wantEqual: true,
+		},
+		{
+			name: "normalizing the protos which differ in the bases of the integer constants",
+			oldInput: `syntax = "proto3";
+option (foo) = 0x10;
+enum E {
+  E_A = 0 [(bar) = 010];
+}
+`,
+			newInput: `syntax = "proto3";
+option (foo) = 16;
+enum E {
+  E_A = 0 [(bar) = 8];
+}
+`,
+			wantEqual: true,
+		},
+		{
+			name: "normalizing the protos which differ in the layout of the aggregate constants",
+			oldInput: `syntax = "proto3";
+option (foo) = {a:1,b:"q",c:{d:[1,2]}};
+message Foo {
+  string a = 1 [(bar) = {a:0x1,b:"q"}];
+}
+`,
+			newInput: `syntax = "proto3";
+option (foo) = {
+  c {
+    d: 1
+    d: 2
+  }
+  b: 'q'
+  a: 1
+};
+message Foo {
+  string a = 1 [(bar) = {a:1
+b:'q'}];
+}
+`,
+			wantEqual: true,
+		},
+		{
+			name: "normalizing the protos which differ in the values of the aggregate constants",
+			oldInput: `syntax = "proto3";
+option (foo) = {a:1,b:"q"};
+`,
+			newInput: `syntax = "proto3";
+option (foo) = {a:1,b:"r"};
+`,
+		},
+		{
+			name: "normalizing the protos which differ in the spellings of the numbers, the imports and the reserved names",
+			oldInput: `syntax = "proto3";
+import 'a.proto';
+option (foo) = 1.0;
+option (bar) = 1.50e1;
+message Foo {
+  int32 a = 0x10;
+  map<string, int32> b = 0x11;
+  oneof o {
+    int32 c = 0x12;
+  }
+  reserved 0x14 to 30;
+  reserved 'foo';
+  enum E {
+    E_A = 0x0;
+  }
+}
+`,
+			newInput: `syntax = "proto3";
+import "a.proto";
+option (foo) = 1.00;
+option (bar) = 15.0;
+message Foo {
+  int32 a = 16;
+  map<string, int32> b = 17;
+  oneof o {
+    int32 c = 18;
+  }
+  reserved 20 to 30;
+  reserved "foo";
+  enum E {
+    E_A = 0;
+  }
+}
+`,
+			wantEqual: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			parse := func(input string) *parser.Proto {
+				p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)), parser.WithPermissive(true))
+				proto, err := p.ParseProto()
+				if err != nil {
+					t.Fatal(err)
+				}
+				return proto
+			}
+
+			oldProto := parse(test.oldInput)
+			newProto := parse(test.newInput)
+			gotEqual := reflect.DeepEqual(parser.Normalize(oldProto), parser.Normalize(newProto))
+			if gotEqual != test.wantEqual {
+				t.Errorf("got %v, but want %v", gotEqual, test.wantEqual)
+			}
+
+			if !reflect.DeepEqual(oldProto, parse(test.oldInput)) {
+				t.Errorf("got the modified original proto")
+			}
+		})
+	}
+}
+
+func TestNormalize_print(t *testing.T) {
+	input := `syntax = "proto2";
+// Foo is a message.
+message Foo {
+  optional string b = 2 [default = "x", deprecated = true];
+  ;
+  optional int32 a = 1; // a
+  option deprecated = true;
+  option (bar) = 1;
+}
+`
+	want := `syntax = "proto2";
+message Foo {
+  option (bar) = 1;
+  option deprecated = true;
+  optional int32 a = 1;
+  optional string b = 2 [default = "x", deprecated = true];
+}
+`
+
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+	proto, err := p.ParseProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := parser.Sprint(parser.Normalize(proto)); got != want {
+		t.Errorf("got %q, but want %q", got, want)
+	}
+	if got := parser.Sprint(proto); !strings.Contains(got, "// Foo is a message.") {
+		t.Errorf("got the original proto without the comment: %q", got)
+	}
+}
+
+func TestNormalize_constants(t *testing.T) {
+	input := `syntax = "proto3";
+option (a) = 'it\'s';
+option (b) = -0x10;
+option (c) = { z: [1, 0x2] y { x: 'w' } v: [] };
+option (d) = FOO;
+option (e) = 1.5;
+option (f) = 1.00;
+option (g) = 2E3;
+option (h) = -inf;
+`
+	want := []string{
+		`"it's"`,
+		`-16`,
+		`{v:[],y:{x:"w"},z:1,z:2}`,
+		`FOO`,
+		`1.5`,
+		`1.0`,
+		`2000.0`,
+		`-inf`,
+	}
+
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)), parser.WithPermissive(true))
+	proto, err := p.ParseProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, element := range parser.Normalize(proto).ProtoBody {
+		got = append(got, element.(*parser.Option).Constant)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, but want %q", got, want)
+	}
+}
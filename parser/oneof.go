@@ -169,6 +169,14 @@ func (p *Parser) ParseOneof() (*Oneof, error) {
 // oneofField = type fieldName "=" fieldNumber [ "[" fieldOptions "]" ] ";"
 // https://developers.google.com/protocol-buffers/docs/reference/proto3-spec#oneof_and_oneof_field
 func (p *Parser) parseOneofField() (*OneofField, error) {
+	p.lex.NextKeyword()
+	switch p.lex.Token {
+	case scanner.TREPEATED, scanner.TREQUIRED, scanner.TOPTIONAL:
+		// Like protoc, a label is rejected regardless of the tokens which follow it.
+		return nil, p.unexpected("oneof field without a label")
+	}
+	p.lex.UnNext()
+
 	typeValue, startPos, err := p.parseType()
 	if err != nil {
 		return nil, p.unexpected("type")
@@ -205,6 +213,9 @@ func (p *Parser) parseOneofField() (*OneofField, error) {
 		FieldName:    fieldName,
 		FieldNumber:  fieldNumber,
 		FieldOptions: fieldOptions,
-		Meta:         meta.Meta{Pos: startPos.Position},
+		Meta: meta.Meta{
+			Pos:     startPos.Position,
+			LastPos: p.lex.Pos.Position,
+		},
 	}, nil
 }
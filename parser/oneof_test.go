@@ -59,6 +59,11 @@ func TestParser_ParseOneof(t *testing.T) {
 								Line:   2,
 								Column: 5,
 							},
+							LastPos: meta.Position{
+								Offset: 31,
+								Line:   2,
+								Column: 20,
+							},
 						},
 					},
 					{
@@ -71,6 +76,11 @@ func TestParser_ParseOneof(t *testing.T) {
 								Line:   3,
 								Column: 5,
 							},
+							LastPos: meta.Position{
+								Offset: 63,
+								Line:   3,
+								Column: 31,
+							},
 						},
 					},
 				},
@@ -109,6 +119,11 @@ func TestParser_ParseOneof(t *testing.T) {
 								Line:   2,
 								Column: 5,
 							},
+							LastPos: meta.Position{
+								Offset: 31,
+								Line:   2,
+								Column: 20,
+							},
 						},
 					},
 					{
@@ -121,6 +136,11 @@ func TestParser_ParseOneof(t *testing.T) {
 								Line:   4,
 								Column: 5,
 							},
+							LastPos: meta.Position{
+								Offset: 69,
+								Line:   4,
+								Column: 31,
+							},
 						},
 					},
 				},
@@ -163,6 +183,11 @@ func TestParser_ParseOneof(t *testing.T) {
 										Line:   2,
 										Column: 5,
 									},
+									LastPos: meta.Position{
+										Offset: 22,
+										Line:   2,
+										Column: 11,
+									},
 								},
 							},
 						},
@@ -172,6 +197,11 @@ func TestParser_ParseOneof(t *testing.T) {
 								Line:   3,
 								Column: 5,
 							},
+							LastPos: meta.Position{
+								Offset: 43,
+								Line:   3,
+								Column: 20,
+							},
 						},
 					},
 					{
@@ -187,6 +217,11 @@ func TestParser_ParseOneof(t *testing.T) {
 										Line:   4,
 										Column: 5,
 									},
+									LastPos: meta.Position{
+										Offset: 62,
+										Line:   4,
+										Column: 18,
+									},
 								},
 							},
 						},
@@ -196,6 +231,11 @@ func TestParser_ParseOneof(t *testing.T) {
 								Line:   5,
 								Column: 5,
 							},
+							LastPos: meta.Position{
+								Offset: 94,
+								Line:   5,
+								Column: 31,
+							},
 						},
 					},
 				},
@@ -235,6 +275,11 @@ func TestParser_ParseOneof(t *testing.T) {
 									Line:   2,
 									Column: 22,
 								},
+								LastPos: meta.Position{
+									Offset: 63,
+									Line:   2,
+									Column: 28,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -243,6 +288,11 @@ func TestParser_ParseOneof(t *testing.T) {
 								Line:   2,
 								Column: 5,
 							},
+							LastPos: meta.Position{
+								Offset: 55,
+								Line:   2,
+								Column: 20,
+							},
 						},
 					},
 					{
@@ -257,6 +307,11 @@ func TestParser_ParseOneof(t *testing.T) {
 									Line:   3,
 									Column: 33,
 								},
+								LastPos: meta.Position{
+									Offset: 110,
+									Line:   3,
+									Column: 46,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -265,6 +320,11 @@ func TestParser_ParseOneof(t *testing.T) {
 								Line:   3,
 								Column: 5,
 							},
+							LastPos: meta.Position{
+								Offset: 95,
+								Line:   3,
+								Column: 31,
+							},
 						},
 					},
 				},
@@ -277,6 +337,11 @@ func TestParser_ParseOneof(t *testing.T) {
 							Line:   1,
 							Column: 13,
 						},
+						LastPos: meta.Position{
+							Offset: 34,
+							Line:   1,
+							Column: 35,
+						},
 					},
 				},
 				Meta: meta.Meta{
@@ -312,6 +377,11 @@ func TestParser_ParseOneof(t *testing.T) {
 								Line:   2,
 								Column: 5,
 							},
+							LastPos: meta.Position{
+								Offset: 31,
+								Line:   2,
+								Column: 20,
+							},
 						},
 					},
 				},
@@ -349,6 +419,11 @@ func TestParser_ParseOneof(t *testing.T) {
 								Line:   2,
 								Column: 5,
 							},
+							LastPos: meta.Position{
+								Offset: 31,
+								Line:   2,
+								Column: 20,
+							},
 						},
 					},
 				},
@@ -387,6 +462,13 @@ func TestParser_ParseOneof(t *testing.T) {
 							{
 								OptionName: "(validator.field)",
 								Constant:   "{int_gt:20}",
+								Meta: meta.Meta{
+									Pos: meta.Position{
+										Offset: 89,
+										Line:   3,
+										Column: 25,
+									},
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -395,6 +477,11 @@ func TestParser_ParseOneof(t *testing.T) {
 								Line:   3,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 122,
+								Line:   3,
+								Column: 58,
+							},
 						},
 					},
 					{
@@ -405,6 +492,13 @@ func TestParser_ParseOneof(t *testing.T) {
 							{
 								OptionName: "(validator.field)",
 								Constant:   "{int_gt:100}",
+								Meta: meta.Meta{
+									Pos: meta.Position{
+										Offset: 147,
+										Line:   4,
+										Column: 24,
+									},
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -413,6 +507,11 @@ func TestParser_ParseOneof(t *testing.T) {
 								Line:   4,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 181,
+								Line:   4,
+								Column: 58,
+							},
 						},
 					},
 					{
@@ -423,6 +522,13 @@ func TestParser_ParseOneof(t *testing.T) {
 							{
 								OptionName: "(validator.field)",
 								Constant:   "{regex:\"^[a-z]{2,5}$\"}",
+								Meta: meta.Meta{
+									Pos: meta.Position{
+										Offset: 208,
+										Line:   5,
+										Column: 26,
+									},
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -431,6 +537,11 @@ func TestParser_ParseOneof(t *testing.T) {
 								Line:   5,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 252,
+								Line:   5,
+								Column: 70,
+							},
 						},
 					},
 				},
@@ -444,6 +555,11 @@ func TestParser_ParseOneof(t *testing.T) {
 								Line:   2,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 63,
+								Line:   2,
+								Column: 46,
+							},
 						},
 					},
 				},
@@ -494,3 +610,49 @@ func TestParser_ParseOneof(t *testing.T) {
 	}
 
 }
+
+func TestParser_ParseOneof_label(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name: "parsing an optional field",
+			input: `oneof foo {
+    string name = 4;
+    optional string x = 1;
+}`,
+			wantErr: `expected [oneof field without a label], found "optional" at <input>:3:5`,
+		},
+		{
+			name: "parsing a repeated field",
+			input: `oneof foo {
+  repeated string x = 1;
+}`,
+			wantErr: `expected [oneof field without a label], found "repeated" at <input>:2:3`,
+		},
+		{
+			name: "parsing a required field of a message type",
+			input: `oneof foo {
+  // a comment
+  required SubMessage x = 1;
+}`,
+			wantErr: `expected [oneof field without a label], found "required" at <input>:3:3`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			_, err := p.ParseOneof()
+			if err == nil {
+				t.Fatalf("got err nil, but want %q", test.wantErr)
+			}
+			if err.Error() != test.wantErr {
+				t.Errorf("got err %q, but want %q", err.Error(), test.wantErr)
+			}
+		})
+	}
+}
@@ -11,6 +11,10 @@ import (
 type Option struct {
 	OptionName string
 	Constant   string
+	// RawConstant is the source text of Constant when it concatenates adjacent string literals,
+	// like `"foo" "bar"` for the Constant `"foobar"`, keeping the whitespace and the comments between them.
+	// It's empty otherwise.
+	RawConstant string
 
 	// Comments are the optional ones placed at the beginning.
 	Comments []*Comment
@@ -39,6 +43,60 @@ func (o *Option) Accept(v Visitor) {
 	}
 }
 
+// QuoteStyle returns the quote used by the string constant, that is either '"' or '\''.
+// It returns 0 when the constant is not a string.
+func (o *Option) QuoteStyle() byte {
+	if len(o.Constant) < 2 {
+		return 0
+	}
+	switch q := o.Constant[0]; q {
+	case '"', '\'':
+		return q
+	default:
+		return 0
+	}
+}
+
+// BoolValue returns the boolean value of the constant and whether the constant is a boolean.
+// Only the canonical spellings, "true" and "false", are recognized.
+func (o *Option) BoolValue() (value bool, ok bool) {
+	return boolConstant(o.Constant)
+}
+
+func boolConstant(constant string) (value bool, ok bool) {
+	switch constant {
+	case "true":
+		return true, true
+	case "false":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// LenientBoolValue is like BoolValue but also recognizes "True" and "False".
+// The numeric constants "1" and "0" are recognized only when acceptNumeric is true.
+// A constant which LenientBoolValue accepts and BoolValue doesn't has a non-canonical spelling,
+// which ParseProto reports through Parser.Warnings unless the parser is permissive.
+func (o *Option) LenientBoolValue(acceptNumeric bool) (value bool, ok bool) {
+	if value, ok := o.BoolValue(); ok {
+		return value, true
+	}
+
+	switch o.Constant {
+	case "True":
+		return true, true
+	case "False":
+		return false, true
+	case "1":
+		return acceptNumeric, acceptNumeric
+	case "0":
+		return false, acceptNumeric
+	default:
+		return false, false
+	}
+}
+
 // ParseOption parses the option.
 //  option = "option" optionName  "=" constant ";"
 //
@@ -60,7 +118,7 @@ func (p *Parser) ParseOption() (*Option, error) {
 		return nil, p.unexpected("=")
 	}
 
-	constant, err := p.parseOptionConstant()
+	constant, raw, err := p.parseRawOptionConstant()
 	if err != nil {
 		return nil, err
 	}
@@ -71,23 +129,30 @@ func (p *Parser) ParseOption() (*Option, error) {
 	}
 
 	return &Option{
-		OptionName: optionName,
-		Constant:   constant,
-		Meta:       meta.Meta{Pos: startPos.Position},
+		OptionName:  optionName,
+		Constant:    constant,
+		RawConstant: rawConstant(constant, raw),
+		Meta: meta.Meta{
+			Pos:     startPos.Position,
+			LastPos: p.lex.Pos.Position,
+		},
 	}, nil
 }
 
 // cloudEndpointsOptionConstant = "{" ident ":" constant { ( ["," | ";" ] ident ":" constant | cloudEndpointsOptionConstant ) } ["," | ";"] "}"
 //
+// "<" and ">" are accepted in place of "{" and "}" as the text format allows, and are normalized to the latter.
+//
 // See https://cloud.google.com/endpoints/docs/grpc-service-config/reference/rpc/google.api
 func (p *Parser) parseCloudEndpointsOptionConstant() (string, error) {
 	var ret string
 
 	p.lex.Next()
-	if p.lex.Token != scanner.TLEFTCURLY {
+	closing, ok := aggregateClosings[p.lex.Token]
+	if !ok {
 		return "", p.unexpected("{")
 	}
-	ret += p.lex.Text
+	ret += "{"
 
 	for {
 		p.lex.Next()
@@ -99,14 +164,14 @@ func (p *Parser) parseCloudEndpointsOptionConstant() (string, error) {
 		needSemi := false
 		p.lex.Next()
 		switch p.lex.Token {
-		case scanner.TLEFTCURLY:
+		case scanner.TLEFTCURLY, scanner.TLESS:
 			if !p.permissive {
 				return "", p.unexpected(":")
 			}
 			p.lex.UnNext()
 		case scanner.TCOLON:
 			ret += p.lex.Text
-			if p.lex.Peek() == scanner.TLEFTCURLY && p.permissive {
+			if isAggregateOpening(p.lex.Peek()) && p.permissive {
 				needSemi = true
 			}
 		default:
@@ -131,13 +196,13 @@ func (p *Parser) parseCloudEndpointsOptionConstant() (string, error) {
 		switch {
 		case p.lex.Token == scanner.TCOMMA, p.lex.Token == scanner.TSEMICOLON:
 			ret += p.lex.Text
-			if p.lex.Peek() == scanner.TRIGHTCURLY && p.permissive {
+			if p.lex.Peek() == closing && p.permissive {
 				p.lex.Next()
-				ret += p.lex.Text
+				ret += "}"
 				return ret, nil
 			}
-		case p.lex.Token == scanner.TRIGHTCURLY:
-			ret += p.lex.Text
+		case p.lex.Token == closing:
+			ret += "}"
 			return ret, nil
 		default:
 			ret += "\n"
@@ -146,30 +211,76 @@ func (p *Parser) parseCloudEndpointsOptionConstant() (string, error) {
 	}
 }
 
-// optionName = ( ident | "(" fullIdent ")" ) { "." ident }
-func (p *Parser) parseOptionName() (string, error) {
-	var optionName string
+// peekIsOption checks whether the next statement is an option rather than a field or an enum value named option.
+// option is treated as a keyword only when an optionName and "=" follow.
+func (p *Parser) peekIsOption() bool {
+	p.lex.NextKeyword()
+	defer p.lex.UnNextTo(p.lex.RawText)
+	if p.lex.Token != scanner.TOPTION {
+		return false
+	}
 
 	p.lex.Next()
+	defer p.lex.UnNextTo(p.lex.RawText)
 	switch p.lex.Token {
-	case scanner.TIDENT:
-		optionName = p.lex.Text
 	case scanner.TLEFTPAREN:
-		optionName = p.lex.Text
-		fullIdent, _, err := p.lex.ReadFullIdent()
-		if err != nil {
-			return "", err
-		}
-		optionName += fullIdent
+		return true
+	case scanner.TIDENT:
+	default:
+		return false
+	}
 
+	for {
 		p.lex.Next()
-		if p.lex.Token != scanner.TRIGHTPAREN {
-			return "", p.unexpected(")")
+		defer p.lex.UnNextTo(p.lex.RawText)
+		switch p.lex.Token {
+		case scanner.TEQUALS:
+			return true
+		case scanner.TDOT:
+			p.lex.Next()
+			defer p.lex.UnNextTo(p.lex.RawText)
+			if p.lex.Token != scanner.TIDENT {
+				return false
+			}
+		default:
+			return false
 		}
-		optionName += p.lex.Text
-	default:
-		return "", p.unexpected("ident or left paren")
 	}
+}
+
+// aggregateClosings maps the opening token of an aggregate to the closing one.
+var aggregateClosings = map[scanner.Token]scanner.Token{
+	scanner.TLEFTCURLY: scanner.TRIGHTCURLY,
+	scanner.TLESS:      scanner.TGREATER,
+}
+
+func isAggregateOpening(token scanner.Token) bool {
+	_, ok := aggregateClosings[token]
+	return ok
+}
+
+// optionName = ( ident | "(" fullIdent ")" ) { "." ( ident | "(" fullIdent ")" ) }
+//
+// A braced name after a dot appears in editions, like `features.(pb.cpp).legacy_closed_enum`.
+func (p *Parser) parseOptionName() (string, error) {
+	parts, err := p.parseOptionNameParts()
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, 0, len(parts))
+	for _, part := range parts {
+		names = append(names, part.String())
+	}
+	return p.lex.Intern(strings.Join(names, ".")), nil
+}
+
+func (p *Parser) parseOptionNameParts() ([]OptionNamePart, error) {
+	part, err := p.parseOptionNamePart()
+	if err != nil {
+		return nil, err
+	}
+	parts := []OptionNamePart{part}
 
 	for {
 		p.lex.Next()
@@ -177,73 +288,109 @@ func (p *Parser) parseOptionName() (string, error) {
 			p.lex.UnNext()
 			break
 		}
-		optionName += p.lex.Text
+
+		part, err := p.parseOptionNamePart()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
+
+// optionNamePart = ident | "(" fullIdent ")"
+func (p *Parser) parseOptionNamePart() (OptionNamePart, error) {
+	p.lex.Next()
+	switch p.lex.Token {
+	case scanner.TIDENT:
+		return OptionNamePart{
+			Name: p.lex.Text,
+		}, nil
+	case scanner.TLEFTPAREN:
+		fullIdent, _, err := p.lex.ReadFullIdent()
+		if err != nil {
+			return OptionNamePart{}, err
+		}
 
 		p.lex.Next()
-		if p.lex.Token != scanner.TIDENT {
-			return "", p.unexpected("ident")
+		if p.lex.Token != scanner.TRIGHTPAREN {
+			return OptionNamePart{}, p.unexpected(")")
 		}
-		optionName += p.lex.Text
+		return OptionNamePart{
+			Name:        fullIdent,
+			IsExtension: true,
+		}, nil
+	default:
+		return OptionNamePart{}, p.unexpected("ident or left paren")
 	}
-	return optionName, nil
 }
 
-func (p *Parser) parseOptionConstant() (constant string, err error) {
+func (p *Parser) parseOptionConstant() (string, error) {
+	constant, _, err := p.parseRawOptionConstant()
+	return constant, err
+}
+
+// parseRawOptionConstant is like parseOptionConstant but also returns the raw spelling of the constant.
+// See lexer.ReadRawConstant for the raw spelling.
+func (p *Parser) parseRawOptionConstant() (constant string, raw string, err error) {
 	switch p.lex.Peek() {
 	// Cloud Endpoints requires this exception.
-	case scanner.TLEFTCURLY:
+	case scanner.TLEFTCURLY, scanner.TLESS:
 		if !p.permissive {
-			return "", p.unexpected("constant or permissive mode")
+			return "", "", p.unexpected("constant or permissive mode")
 		}
 
 		// parses empty fields within an option
-		if p.lex.PeekN(2) == scanner.TRIGHTCURLY {
+		if p.lex.PeekN(2) == aggregateClosings[p.lex.Peek()] {
 			p.lex.NextN(2)
-			return "{}", nil
+			return "{}", "{}", nil
 		}
 
 		constant, err = p.parseCloudEndpointsOptionConstant()
 		if err != nil {
-			return "", err
+			return "", "", err
 		}
+		raw = constant
 
 	case scanner.TLEFTSQUARE:
 		if !p.permissive {
-			return "", p.unexpected("constant or permissive mode")
+			return "", "", p.unexpected("constant or permissive mode")
 		}
 		p.lex.Next()
 
 		// parses empty fields within an option
 		if p.lex.Peek() == scanner.TRIGHTSQUARE {
 			p.lex.Next()
-			return "[]", nil
+			return "[]", "[]", nil
 		}
 
-		constant, err = p.parseOptionConstants()
+		constant, raw, err = p.parseOptionConstants()
 		if err != nil {
-			return "", err
+			return "", "", err
 		}
 		p.lex.Next()
 		constant = "[" + constant + "]"
+		raw = "[" + raw + "]"
 
 	default:
-		constant, _, err = p.lex.ReadConstant(p.permissive)
+		constant, raw, _, err = p.lex.ReadRawConstant()
 		if err != nil {
-			return "", err
+			return "", "", err
 		}
 	}
-	return constant, nil
+	return constant, raw, nil
 }
 
 // optionConstants = optionConstant { ","  optionConstant }
-func (p *Parser) parseOptionConstants() (constant string, err error) {
-	opt, err := p.parseOptionConstant()
+func (p *Parser) parseOptionConstants() (constant string, raw string, err error) {
+	opt, optRaw, err := p.parseRawOptionConstant()
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	var opts []string
+	var opts, raws []string
 	opts = append(opts, opt)
+	raws = append(raws, optRaw)
 
 	for {
 		p.lex.Next()
@@ -252,11 +399,20 @@ func (p *Parser) parseOptionConstants() (constant string, err error) {
 			break
 		}
 
-		opt, err = p.parseOptionConstant()
+		opt, optRaw, err = p.parseRawOptionConstant()
 		if err != nil {
-			return "", p.unexpected("optionConstant")
+			return "", "", p.unexpected("optionConstant")
 		}
 		opts = append(opts, opt)
+		raws = append(raws, optRaw)
+	}
+	return strings.Join(opts, ","), strings.Join(raws, ","), nil
+}
+
+// rawConstant returns the raw spelling when it differs from the constant, and an empty string otherwise.
+func rawConstant(constant, raw string) string {
+	if raw == constant {
+		return ""
 	}
-	return strings.Join(opts, ","), nil
+	return raw
 }
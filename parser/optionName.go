@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+)
+
+// OptionNamePart is a part of an option name, which is separated by the dots outside the parentheses.
+// For example, `(complex.nested.ext).field` consists of the extension "complex.nested.ext" and the field "field".
+type OptionNamePart struct {
+	// Name is the ident of a field, or the fullIdent of an extension without the parentheses.
+	Name string
+	// IsExtension reports whether the part is an extension enclosed in parentheses.
+	IsExtension bool
+}
+
+// String returns the part as written in the option name, enclosing an extension in parentheses.
+func (p OptionNamePart) String() string {
+	if p.IsExtension {
+		return "(" + p.Name + ")"
+	}
+	return p.Name
+}
+
+// OptionNameParts interprets the OptionName into OptionNameParts.
+func (o *Option) OptionNameParts() ([]OptionNamePart, error) {
+	return ParseOptionName(o.OptionName)
+}
+
+// OptionNameParts interprets the OptionName into OptionNameParts.
+func (f *FieldOption) OptionNameParts() ([]OptionNamePart, error) {
+	return ParseOptionName(f.OptionName)
+}
+
+// OptionNameParts interprets the OptionName into OptionNameParts.
+func (e *EnumValueOption) OptionNameParts() ([]OptionNamePart, error) {
+	return ParseOptionName(e.OptionName)
+}
+
+// ParseOptionName splits an option name, such as Option.OptionName and FieldOption.OptionName, into the parts.
+func ParseOptionName(name string) ([]OptionNamePart, error) {
+	p := NewParser(lexer.NewLexer(strings.NewReader(name)))
+	parts, err := p.parseOptionNameParts()
+	if err != nil {
+		return nil, err
+	}
+
+	p.lex.Next()
+	if !p.lex.IsEOF() {
+		return nil, p.unexpected("EOF")
+	}
+	return parts, nil
+}
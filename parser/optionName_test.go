@@ -0,0 +1,154 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestParseOptionName(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantParts []parser.OptionNamePart
+		wantErr   bool
+	}{
+		{
+			name:  "parsing a builtin option name",
+			input: "java_package",
+			wantParts: []parser.OptionNamePart{
+				{Name: "java_package"},
+			},
+		},
+		{
+			name:  "parsing an extension followed by the fields",
+			input: "(complex.nested.ext).field.subfield",
+			wantParts: []parser.OptionNamePart{
+				{Name: "complex.nested.ext", IsExtension: true},
+				{Name: "field"},
+				{Name: "subfield"},
+			},
+		},
+		{
+			name:  "parsing an extension after a field",
+			input: "features.(pb.cpp).legacy_closed_enum",
+			wantParts: []parser.OptionNamePart{
+				{Name: "features"},
+				{Name: "pb.cpp", IsExtension: true},
+				{Name: "legacy_closed_enum"},
+			},
+		},
+		{
+			name:    "parsing an unclosed extension",
+			input:   "(complex.ext.field",
+			wantErr: true,
+		},
+		{
+			name:    "parsing a trailing dot",
+			input:   "(complex.ext).",
+			wantErr: true,
+		},
+		{
+			name:    "parsing a trailing text",
+			input:   "(complex.ext) field",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parser.ParseOptionName(test.input)
+			switch {
+			case test.wantErr:
+				if err == nil {
+					t.Errorf("got err nil, but want err")
+				}
+				return
+			case err != nil:
+				t.Fatalf("got err %v, but want nil", err)
+			}
+
+			if !reflect.DeepEqual(got, test.wantParts) {
+				t.Errorf("got %v, but want %v", got, test.wantParts)
+			}
+		})
+	}
+}
+
+func TestOption_OptionNameParts(t *testing.T) {
+	input := `message Foo {
+  option (complex.nested.ext).field = 1;
+  string a = 1 [(my.ext).sub = true];
+  enum E {
+    E_A = 0 [(my.enum_ext) = "x"];
+  }
+}`
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+	message, err := p.ParseMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	option := message.MessageBody[0].(*parser.Option)
+	fieldOption := message.MessageBody[1].(*parser.Field).FieldOptions[0]
+	enumValueOption := message.MessageBody[2].(*parser.Enum).EnumBody[0].(*parser.EnumField).EnumValueOptions[0]
+
+	tests := []struct {
+		name           string
+		optionName     string
+		parts          func() ([]parser.OptionNamePart, error)
+		wantOptionName string
+		wantParts      []parser.OptionNamePart
+	}{
+		{
+			name:           "interpreting the name of an option",
+			optionName:     option.OptionName,
+			parts:          option.OptionNameParts,
+			wantOptionName: "(complex.nested.ext).field",
+			wantParts: []parser.OptionNamePart{
+				{Name: "complex.nested.ext", IsExtension: true},
+				{Name: "field"},
+			},
+		},
+		{
+			name:           "interpreting the name of a field option",
+			optionName:     fieldOption.OptionName,
+			parts:          fieldOption.OptionNameParts,
+			wantOptionName: "(my.ext).sub",
+			wantParts: []parser.OptionNamePart{
+				{Name: "my.ext", IsExtension: true},
+				{Name: "sub"},
+			},
+		},
+		{
+			name:           "interpreting the name of an enum value option",
+			optionName:     enumValueOption.OptionName,
+			parts:          enumValueOption.OptionNameParts,
+			wantOptionName: "(my.enum_ext)",
+			wantParts: []parser.OptionNamePart{
+				{Name: "my.enum_ext", IsExtension: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if test.optionName != test.wantOptionName {
+				t.Errorf("got %q, but want %q", test.optionName, test.wantOptionName)
+			}
+
+			got, err := test.parts()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.wantParts) {
+				t.Errorf("got %v, but want %v", got, test.wantParts)
+			}
+		})
+	}
+}
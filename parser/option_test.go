@@ -49,6 +49,11 @@ func TestParser_ParseOption(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 39,
+						Line:   1,
+						Column: 40,
+					},
 				},
 			},
 		},
@@ -64,6 +69,11 @@ func TestParser_ParseOption(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 27,
+						Line:   1,
+						Column: 28,
+					},
 				},
 			},
 		},
@@ -79,6 +89,11 @@ func TestParser_ParseOption(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 47,
+						Line:   1,
+						Column: 48,
+					},
 				},
 			},
 		},
@@ -100,6 +115,11 @@ rest_collection:"projects.addresses"}`,
 						Line:   2,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 131,
+						Line:   5,
+						Column: 2,
+					},
 				},
 			},
 		},
@@ -121,6 +141,11 @@ option (google.api.http) = {
 						Line:   2,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 111,
+						Line:   6,
+						Column: 2,
+					},
 				},
 			},
 		},
@@ -143,6 +168,11 @@ option (google.api.http) = {
 						Line:   2,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 122,
+						Line:   7,
+						Column: 2,
+					},
 				},
 			},
 		},
@@ -165,6 +195,11 @@ option (google.api.http) = {
 						Line:   2,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 113,
+						Line:   7,
+						Column: 2,
+					},
 				},
 			},
 		},
@@ -185,6 +220,11 @@ option (google.api.http) = {
 						Line:   2,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 76,
+						Line:   5,
+						Column: 2,
+					},
 				},
 			},
 		},
@@ -207,6 +247,11 @@ option (opt) = {
 						Line:   2,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 76,
+						Line:   7,
+						Column: 2,
+					},
 				},
 			},
 		},
@@ -239,6 +284,133 @@ body:"*"}]}`,
 						Line:   2,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 265,
+						Line:   14,
+						Column: 2,
+					},
+				},
+			},
+		},
+		{
+			name:  "parsing a single-quoted constant",
+			input: `option java_package = 'com.example.foo';`,
+			wantOption: &parser.Option{
+				OptionName: "java_package",
+				Constant:   `'com.example.foo'`,
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 39,
+						Line:   1,
+						Column: 40,
+					},
+				},
+			},
+		},
+		{
+			name:       "parsing concatenated constants with mixed quotes by permissive mode",
+			input:      `option java_package = 'com.' "example" '.foo';`,
+			permissive: true,
+			wantOption: &parser.Option{
+				OptionName:  "java_package",
+				Constant:    `'com.example.foo'`,
+				RawConstant: `'com.' "example" '.foo'`,
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 45,
+						Line:   1,
+						Column: 46,
+					},
+				},
+			},
+		},
+		{
+			name: "parses angle brackets in place of curly braces",
+			input: `
+option (google.api.http) = <
+    post: "/v1/resources",
+    additional_bindings: <
+		post: "/v2/resources"
+	>;
+    empty: <>,
+>;`,
+			permissive: true,
+			wantOption: &parser.Option{
+				OptionName: "(google.api.http)",
+				Constant:   "{post:\"/v1/resources\",additional_bindings:{post:\"/v2/resources\"};\nempty:{},}",
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 1,
+						Line:   2,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 128,
+						Line:   8,
+						Column: 2,
+					},
+				},
+			},
+		},
+		{
+			name: "parsing an invalid; mismatched angle bracket and curly brace",
+			input: `
+option (google.api.http) = <
+    post: "/v1/resources"
+};`,
+			permissive: true,
+			wantErr:    true,
+		},
+		{
+			name: "parsing concatenated constants without permissive mode",
+			input: `option java_package = "com."
+  "example.foo";`,
+			wantOption: &parser.Option{
+				OptionName:  "java_package",
+				Constant:    `"com.example.foo"`,
+				RawConstant: "\"com.\"\n  \"example.foo\"",
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 44,
+						Line:   2,
+						Column: 16,
+					},
+				},
+			},
+		},
+		{
+			name:  "parsing concatenated constants separated by a tab and a comment",
+			input: "option java_package = \"com.\"\t/* c */\n  \"example.foo\";",
+			wantOption: &parser.Option{
+				OptionName:  "java_package",
+				Constant:    `"com.example.foo"`,
+				RawConstant: "\"com.\"\t/* c */\n  \"example.foo\"",
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 52,
+						Line:   2,
+						Column: 16,
+					},
 				},
 			},
 		},
@@ -271,3 +443,195 @@ body:"*"}]}`,
 	}
 
 }
+
+func TestOption_QuoteStyle(t *testing.T) {
+	tests := []struct {
+		name           string
+		inputOption    *parser.Option
+		wantQuoteStyle byte
+	}{
+		{
+			name: "double-quoted",
+			inputOption: &parser.Option{
+				Constant: `"com.example.foo"`,
+			},
+			wantQuoteStyle: '"',
+		},
+		{
+			name: "single-quoted",
+			inputOption: &parser.Option{
+				Constant: `'com.example.foo'`,
+			},
+			wantQuoteStyle: '\'',
+		},
+		{
+			name: "not a string",
+			inputOption: &parser.Option{
+				Constant: `true`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got := test.inputOption.QuoteStyle()
+			if got != test.wantQuoteStyle {
+				t.Errorf("got %q, but want %q", got, test.wantQuoteStyle)
+			}
+		})
+	}
+}
+
+func TestOption_BoolValue(t *testing.T) {
+	tests := []struct {
+		name               string
+		inputConstant      string
+		inputAcceptNumeric bool
+		wantValue          bool
+		wantOK             bool
+		wantLenientValue   bool
+		wantLenientOK      bool
+	}{
+		{
+			name:             "true",
+			inputConstant:    "true",
+			wantValue:        true,
+			wantOK:           true,
+			wantLenientValue: true,
+			wantLenientOK:    true,
+		},
+		{
+			name:          "false",
+			inputConstant: "false",
+			wantOK:        true,
+			wantLenientOK: true,
+		},
+		{
+			name:             "capitalized True",
+			inputConstant:    "True",
+			wantLenientValue: true,
+			wantLenientOK:    true,
+		},
+		{
+			name:          "capitalized False",
+			inputConstant: "False",
+			wantLenientOK: true,
+		},
+		{
+			name:          "1 without accepting numerics",
+			inputConstant: "1",
+		},
+		{
+			name:               "1 with accepting numerics",
+			inputConstant:      "1",
+			inputAcceptNumeric: true,
+			wantLenientValue:   true,
+			wantLenientOK:      true,
+		},
+		{
+			name:               "0 with accepting numerics",
+			inputConstant:      "0",
+			inputAcceptNumeric: true,
+			wantLenientOK:      true,
+		},
+		{
+			name:               "not a boolean",
+			inputConstant:      `"true"`,
+			inputAcceptNumeric: true,
+		},
+		{
+			name:               "uppercase TRUE",
+			inputConstant:      "TRUE",
+			inputAcceptNumeric: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			option := &parser.Option{
+				Constant: test.inputConstant,
+			}
+
+			value, ok := option.BoolValue()
+			if value != test.wantValue || ok != test.wantOK {
+				t.Errorf("got (%v, %v), but want (%v, %v)", value, ok, test.wantValue, test.wantOK)
+			}
+
+			value, ok = option.LenientBoolValue(test.inputAcceptNumeric)
+			if value != test.wantLenientValue || ok != test.wantLenientOK {
+				t.Errorf("got (%v, %v), but want (%v, %v)", value, ok, test.wantLenientValue, test.wantLenientOK)
+			}
+		})
+	}
+}
+
+func TestParser_ParseOption_numericConstant(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantConstant string
+		wantKind     parser.ConstantKind
+	}{
+		{
+			name:         "parsing a negative decimal",
+			input:        `option (x) = -3;`,
+			wantConstant: "-3",
+			wantKind:     parser.ConstantKindInt,
+		},
+		{
+			name:         "parsing a hexadecimal",
+			input:        `option (x) = 0x1F;`,
+			wantConstant: "0x1F",
+			wantKind:     parser.ConstantKindInt,
+		},
+		{
+			name:         "parsing an octal",
+			input:        `option (x) = 0755;`,
+			wantConstant: "0755",
+			wantKind:     parser.ConstantKindInt,
+		},
+		{
+			name:         "parsing a positive hexadecimal",
+			input:        `option (x) = +0XaB;`,
+			wantConstant: "+0XaB",
+			wantKind:     parser.ConstantKindInt,
+		},
+		{
+			name:         "parsing a negative octal",
+			input:        `option (x) = -017;`,
+			wantConstant: "-017",
+			wantKind:     parser.ConstantKindInt,
+		},
+		{
+			name:         "parsing a negative float with an exponent",
+			input:        `option (x) = -1.5e-3;`,
+			wantConstant: "-1.5e-3",
+			wantKind:     parser.ConstantKindFloat,
+		},
+		{
+			name:         "parsing a negative inf",
+			input:        `option (x) = -inf;`,
+			wantConstant: "-inf",
+			wantKind:     parser.ConstantKindFloat,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			got, err := p.ParseOption()
+			if err != nil {
+				t.Fatalf("got err %v, but want nil", err)
+			}
+			if got.Constant != test.wantConstant {
+				t.Errorf("got %q, but want %q", got.Constant, test.wantConstant)
+			}
+			if got.Kind() != test.wantKind {
+				t.Errorf("got %v, but want %v", got.Kind(), test.wantKind)
+			}
+		})
+	}
+}
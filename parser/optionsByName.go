@@ -0,0 +1,107 @@
+package parser
+
+// OptionsByName returns the option statements in the message which have the given name, such as "(my.custom)", in order.
+// The options of the nested messages, enums and oneofs are included. The field options aren't, which aren't statements.
+func (m *Message) OptionsByName(name string) []*Option {
+	c := &optionCollector{name: name}
+	m.Accept(c)
+	return c.options
+}
+
+// OptionsByName returns the option statements in the proto which have the given name, such as "(my.custom)", in order.
+// The file options and the options of the messages, enums, oneofs, services and rpcs are included.
+// The field options aren't, which aren't statements.
+func (p *Proto) OptionsByName(name string) []*Option {
+	c := &optionCollector{name: name}
+	p.Accept(c)
+	return c.options
+}
+
+type optionCollector struct {
+	name    string
+	options []*Option
+}
+
+func (c *optionCollector) VisitComment(*Comment) {}
+
+func (c *optionCollector) VisitEdition(*Edition) bool {
+	return false
+}
+
+func (c *optionCollector) VisitEmptyStatement(*EmptyStatement) bool {
+	return false
+}
+
+func (c *optionCollector) VisitEnum(*Enum) bool {
+	return true
+}
+
+func (c *optionCollector) VisitEnumField(*EnumField) bool {
+	return false
+}
+
+func (c *optionCollector) VisitExtend(*Extend) bool {
+	return true
+}
+
+func (c *optionCollector) VisitExtensions(*Extensions) bool {
+	return false
+}
+
+func (c *optionCollector) VisitField(*Field) bool {
+	return false
+}
+
+func (c *optionCollector) VisitGroupField(*GroupField) bool {
+	return true
+}
+
+func (c *optionCollector) VisitImport(*Import) bool {
+	return false
+}
+
+func (c *optionCollector) VisitMapField(*MapField) bool {
+	return false
+}
+
+func (c *optionCollector) VisitMessage(*Message) bool {
+	return true
+}
+
+func (c *optionCollector) VisitOneof(*Oneof) bool {
+	return true
+}
+
+func (c *optionCollector) VisitOneofField(*OneofField) bool {
+	return false
+}
+
+func (c *optionCollector) VisitOption(o *Option) bool {
+	if o.OptionName == c.name {
+		c.options = append(c.options, o)
+	}
+	return false
+}
+
+func (c *optionCollector) VisitPackage(*Package) bool {
+	return false
+}
+
+func (c *optionCollector) VisitReserved(*Reserved) bool {
+	return false
+}
+
+func (c *optionCollector) VisitRPC(rpc *RPC) bool {
+	for _, option := range rpc.Options {
+		option.Accept(c)
+	}
+	return false
+}
+
+func (c *optionCollector) VisitService(*Service) bool {
+	return true
+}
+
+func (c *optionCollector) VisitSyntax(*Syntax) bool {
+	return false
+}
@@ -0,0 +1,111 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
+)
+
+const optionsByNameInput = `syntax = "proto3";
+option (my.custom) = 1;
+message Foo {
+  option (my.custom) = 2;
+  string a = 1 [(my.custom) = 3];
+  option deprecated = true;
+  message Bar {
+    option (my.custom) = 4;
+  }
+  oneof o {
+    option (my.custom) = 5;
+    string b = 2;
+  }
+  enum E {
+    option (my.custom) = 6;
+    E_A = 0;
+  }
+}
+service S {
+  option (my.custom) = 7;
+  rpc R (Foo) returns (Foo) {
+    option (my.custom) = 8;
+  }
+}
+`
+
+func TestProto_OptionsByName(t *testing.T) {
+	tests := []struct {
+		name          string
+		optionName    string
+		wantConstants []string
+		wantLines     []int
+	}{
+		{
+			name:          "collecting the custom options in the file",
+			optionName:    "(my.custom)",
+			wantConstants: []string{"1", "2", "4", "5", "6", "7", "8"},
+			wantLines:     []int{2, 4, 8, 11, 15, 20, 22},
+		},
+		{
+			name:          "collecting the builtin options in the file",
+			optionName:    "deprecated",
+			wantConstants: []string{"true"},
+			wantLines:     []int{6},
+		},
+		{
+			name:       "collecting no options",
+			optionName: "(other)",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(optionsByNameInput)), parser.WithPermissive(true))
+			proto, err := p.ParseProto()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var gotConstants []string
+			var gotLines []int
+			for _, option := range proto.OptionsByName(test.optionName) {
+				gotConstants = append(gotConstants, option.Constant)
+				gotLines = append(gotLines, option.Meta.Pos.Line)
+			}
+			if !reflect.DeepEqual(gotConstants, test.wantConstants) {
+				t.Errorf("got %v, but want %v", gotConstants, test.wantConstants)
+			}
+			if !reflect.DeepEqual(gotLines, test.wantLines) {
+				t.Errorf("got %v, but want %v", gotLines, test.wantLines)
+			}
+		})
+	}
+}
+
+func TestMessage_OptionsByName(t *testing.T) {
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(optionsByNameInput)), parser.WithPermissive(true))
+	proto, err := p.ParseProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := proto.ProtoBody[1].(*parser.Message)
+
+	got := message.OptionsByName("(my.custom)")
+	var gotConstants []string
+	for _, option := range got {
+		gotConstants = append(gotConstants, option.Constant)
+	}
+	wantConstants := []string{"2", "4", "5", "6"}
+	if !reflect.DeepEqual(gotConstants, wantConstants) {
+		t.Errorf("got %v, but want %v", gotConstants, wantConstants)
+	}
+
+	wantPos := meta.Position{Offset: 59, Line: 4, Column: 3}
+	if got[0].Meta.Pos != wantPos {
+		t.Errorf("got %v, but want %v", got[0].Meta.Pos, wantPos)
+	}
+}
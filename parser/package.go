@@ -59,6 +59,9 @@ func (p *Parser) ParsePackage() (*Package, error) {
 
 	return &Package{
 		Name: ident,
-		Meta: meta.Meta{Pos: startPos.Position},
+		Meta: meta.Meta{
+			Pos:     startPos.Position,
+			LastPos: p.lex.Pos.Position,
+		},
 	}, nil
 }
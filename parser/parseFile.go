@@ -0,0 +1,41 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+)
+
+// FileError is the error of reading the file in ParseFile, which is distinct from the errors of parsing it.
+type FileError struct {
+	// Path is the path of the file.
+	Path string
+	// Err is the underlying I/O error like *os.PathError.
+	Err error
+}
+
+func (e *FileError) Error() string {
+	return fmt.Sprintf("failed to read the proto file: %v", e.Err)
+}
+
+// Unwrap returns the underlying I/O error.
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// ParseFile reads the file at the path and parses it as ParseProto does, setting the path to the filename of the positions.
+// A failure to read the file is reported as *FileError, and a failure to parse it as the error of ParseProto.
+func ParseFile(path string, opts ...ConfigOption) (*Proto, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, &FileError{
+			Path: path,
+			Err:  err,
+		}
+	}
+
+	p := NewParser(lexer.NewLexer(bytes.NewReader(content), lexer.WithFilename(path)), opts...)
+	return p.ParseProto()
+}
@@ -0,0 +1,84 @@
+package parser_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestParseFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "parseFile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	valid := filepath.Join(dir, "valid.proto")
+	if err := ioutil.WriteFile(valid, []byte("syntax = \"proto3\";\nmessage Foo {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	broken := filepath.Join(dir, "broken.proto")
+	if err := ioutil.WriteFile(broken, []byte("syntax = \"proto3\";\nmessage {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		path         string
+		wantFileErr  bool
+		wantParseErr bool
+	}{
+		{
+			name: "parsing a valid file",
+			path: valid,
+		},
+		{
+			name:        "parsing a missing file",
+			path:        filepath.Join(dir, "missing.proto"),
+			wantFileErr: true,
+		},
+		{
+			name:         "parsing a broken file",
+			path:         broken,
+			wantParseErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			proto, err := parser.ParseFile(test.path)
+
+			var fileErr *parser.FileError
+			if got := errors.As(err, &fileErr); got != test.wantFileErr {
+				t.Fatalf("got %v, but want the file error %v", err, test.wantFileErr)
+			}
+			if test.wantFileErr {
+				if fileErr.Path != test.path || !os.IsNotExist(fileErr.Err) {
+					t.Errorf("got %#v, but want the not-exist error of %s", fileErr, test.path)
+				}
+				return
+			}
+
+			var parseErr *parser.Error
+			if got := errors.As(err, &parseErr); got != test.wantParseErr {
+				t.Fatalf("got %v, but want the parse error %v", err, test.wantParseErr)
+			}
+			if test.wantParseErr {
+				if parseErr.Pos.Filename != test.path {
+					t.Errorf("got %q, but want %q", parseErr.Pos.Filename, test.path)
+				}
+				return
+			}
+
+			message := proto.ProtoBody[0].(*parser.Message)
+			if message.Meta.Pos.Filename != test.path {
+				t.Errorf("got %q, but want %q", message.Meta.Pos.Filename, test.path)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package parser
+
+import "github.com/yoheimuta/go-protoparser/v4/internal/lexer/scanner"
+
+// NodeKind is a kind of the top-level declaration.
+type NodeKind int
+
+// NodeKinds.
+const (
+	NodeKindImport NodeKind = iota
+	NodeKindPackage
+	NodeKindOption
+	NodeKindMessage
+	NodeKindEnum
+	NodeKindService
+	NodeKindExtend
+)
+
+var nodeKindTokens = map[scanner.Token]NodeKind{
+	scanner.TIMPORT:  NodeKindImport,
+	scanner.TPACKAGE: NodeKindPackage,
+	scanner.TOPTION:  NodeKindOption,
+	scanner.TMESSAGE: NodeKindMessage,
+	scanner.TENUM:    NodeKindEnum,
+	scanner.TSERVICE: NodeKindService,
+	scanner.TEXTEND:  NodeKindExtend,
+}
+
+// ParseOnly parses the proto, but retains only the top-level declarations of the given kinds in order.
+// The bodies of the other messages, enums, services and extends are skipped without being parsed.
+func (p *Parser) ParseOnly(kinds ...NodeKind) ([]Visitee, error) {
+	retained := make(map[NodeKind]bool)
+	for _, kind := range kinds {
+		retained[kind] = true
+	}
+
+	_, _, err := p.parseSyntaxOrEdition()
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []Visitee
+	for {
+		comments := p.ParseComments()
+		if p.IsEOF() {
+			return nodes, nil
+		}
+
+		p.lex.NextKeyword()
+		token := p.lex.Token
+		p.lex.UnNext()
+
+		kind, ok := nodeKindTokens[token]
+		if ok && !retained[kind] && isBlockDeclaration(kind) {
+			err := p.skipBlockDeclaration()
+			if err != nil {
+				return nil, err
+			}
+			p.parseInlineComment()
+			continue
+		}
+
+		stmt, err := p.parseProtoBodyStatement(token, comments)
+		if err != nil {
+			return nil, err
+		}
+		p.MaybeScanInlineComment(stmt)
+
+		if ok && retained[kind] {
+			nodes = append(nodes, stmt)
+		}
+	}
+}
+
+func isBlockDeclaration(kind NodeKind) bool {
+	switch kind {
+	case NodeKindMessage, NodeKindEnum, NodeKindService, NodeKindExtend:
+		return true
+	default:
+		return false
+	}
+}
+
+// skipBlockDeclaration skips the declaration up to the "}" matching the first "{".
+// String literals are scanned as a whole and comments are ignored, so the braces in them don't count.
+func (p *Parser) skipBlockDeclaration() error {
+	depth := 0
+	for {
+		p.lex.NextLit()
+		switch p.lex.Token {
+		case scanner.TLEFTCURLY:
+			depth++
+		case scanner.TRIGHTCURLY:
+			depth--
+			if depth == 0 {
+				return nil
+			}
+			if depth < 0 {
+				return p.unexpected("{")
+			}
+		case scanner.TEOF:
+			return p.unexpected("}")
+		}
+	}
+}
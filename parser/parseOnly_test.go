@@ -0,0 +1,127 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestParser_ParseOnly(t *testing.T) {
+	input := `
+syntax = "proto3";
+package foo;
+option go_package = "foo";
+
+message SearchRequest {
+  // a comment with an unbalanced brace }
+  string query = 1 [(validate.rules).string = { pattern: "^{[a-z]+$" }];
+  message Inner {
+    enum Corpus {
+      UNIVERSAL = 0;
+    }
+  }
+} // end of SearchRequest
+
+service SearchService {
+  rpc Search (SearchRequest) returns (SearchResponse);
+}
+
+enum Color { RED = 0; };
+
+// Another service.
+service AnotherService {
+  rpc Find (SearchRequest) returns (SearchResponse) {
+    option (google.api.http) = { get: "/v1/{name}" };
+  }
+}
+`
+
+	tests := []struct {
+		name      string
+		input     string
+		inputKind []parser.NodeKind
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name:      "parsing only services",
+			input:     input,
+			inputKind: []parser.NodeKind{parser.NodeKindService},
+			wantNames: []string{"SearchService", "AnotherService"},
+		},
+		{
+			name:      "parsing only messages and enums",
+			input:     input,
+			inputKind: []parser.NodeKind{parser.NodeKindMessage, parser.NodeKindEnum},
+			wantNames: []string{"SearchRequest", "Color"},
+		},
+		{
+			name:      "parsing only packages",
+			input:     input,
+			inputKind: []parser.NodeKind{parser.NodeKindPackage},
+			wantNames: []string{"foo"},
+		},
+		{
+			name:  "parsing nothing",
+			input: input,
+		},
+		{
+			name: "parsing an unterminated message",
+			input: `
+syntax = "proto3";
+message SearchRequest {
+  message Inner {
+}
+`,
+			inputKind: []parser.NodeKind{parser.NodeKindService},
+			wantErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(
+				lexer.NewLexer(strings.NewReader(test.input)),
+				parser.WithPermissive(true),
+			)
+			got, err := p.ParseOnly(test.inputKind...)
+			switch {
+			case test.wantErr:
+				if err == nil {
+					t.Errorf("got err nil, but want err, parsed=%v", got)
+				}
+				return
+			case !test.wantErr && err != nil:
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			var gotNames []string
+			for _, node := range got {
+				switch n := node.(type) {
+				case *parser.Service:
+					gotNames = append(gotNames, n.ServiceName)
+				case *parser.Message:
+					gotNames = append(gotNames, n.MessageName)
+				case *parser.Enum:
+					gotNames = append(gotNames, n.EnumName)
+				case *parser.Package:
+					gotNames = append(gotNames, n.Name)
+				default:
+					t.Errorf("got unexpected node %T", node)
+				}
+			}
+			if !reflect.DeepEqual(gotNames, test.wantNames) {
+				t.Errorf("got %v, but want %v", gotNames, test.wantNames)
+			}
+
+			if !p.IsEOF() {
+				t.Errorf("got not eof, but want eof")
+			}
+		})
+	}
+}
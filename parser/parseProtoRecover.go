@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"context"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer/scanner"
+	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
+)
+
+// ParseProtoRecover is like ParseProto but doesn't stop at the first error, which suits language servers.
+// It returns the proto consisting of the declarations parsed successfully, along with the errors in order.
+//
+// A top-level declaration which fails to parse is skipped as a whole, and the parsing resumes after it.
+// The skipped declaration ends at the first ";" outside braces, or at the "}" closing its first brace
+// followed by an optional ";". A broken syntax or edition declaration is skipped likewise, leaving both
+// Syntax and Edition nil.
+func (p *Parser) ParseProtoRecover() (*Proto, []*Error) {
+	var errs []*Error
+	onError := func(err error, start scanner.Position) {
+		errs = append(errs, p.recoverableError(err, start))
+		p.skipStatement(start)
+	}
+
+	p.lex.Next()
+	start := p.lex.Pos
+	p.lex.UnNext()
+	syntax, edition, err := p.parseSyntaxOrEdition()
+	if err != nil {
+		onError(err, start)
+	}
+
+	// The body never fails without the context canceled.
+	protoBody, _ := p.parseProtoBody(context.Background(), onError)
+	return p.newProto(syntax, edition, protoBody), errs
+}
+
+// recoverableError converts the error of the statement beginning at start into an Error.
+func (p *Parser) recoverableError(err error, start scanner.Position) *Error {
+	if perr, ok := p.withLineText(err).(*Error); ok {
+		return perr
+	}
+	// Every parse error wraps a meta.Error, but falls back on the start of the statement just in case.
+	return &Error{
+		Pos:      start.Position,
+		LineText: p.lex.LineText(start.Line),
+		err: &meta.Error{
+			Pos:      start.Position,
+			Expected: err.Error(),
+		},
+	}
+}
+
+// skipStatement skips the top-level statement beginning at start.
+func (p *Parser) skipStatement(start scanner.Position) {
+	p.lex.Rewind(start)
+
+	depth := 0
+	for {
+		p.lex.NextStrLit()
+		switch p.lex.Token {
+		case scanner.TEOF:
+			return
+		case scanner.TSEMICOLON:
+			if depth == 0 {
+				return
+			}
+		case scanner.TLEFTCURLY:
+			depth++
+		case scanner.TRIGHTCURLY:
+			depth--
+			if depth <= 0 {
+				p.lex.ConsumeToken(scanner.TSEMICOLON)
+				return
+			}
+		}
+	}
+}
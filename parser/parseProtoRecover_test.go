@@ -0,0 +1,124 @@
+package parser_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestParser_ParseProtoRecover(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantSyntax string
+		wantBody   []string
+		wantErrs   []string
+	}{
+		{
+			name: "parsing a proto without errors",
+			input: `syntax = "proto3";
+package foo;
+message A {}
+`,
+			wantSyntax: "proto3",
+			wantBody:   []string{"package foo", "message A"},
+		},
+		{
+			name: "skipping a message with a typo in a field",
+			input: `syntax = "proto3";
+message A {
+  int32 a 1;
+  message Inner {
+    int32 b = 1;
+  }
+}
+message B {
+  int32 b = 1;
+}
+`,
+			wantSyntax: "proto3",
+			wantBody:   []string{"message B"},
+			wantErrs:   []string{`expected [=], found "1" at <input>:3:11`},
+		},
+		{
+			name: "skipping multiple broken declarations",
+			input: `syntax = "proto3";
+import "a.proto"
+option (x) = { a: 1 b };
+message A {}
+enum E { X 1; }
+servce S {}
+message B {}
+`,
+			wantSyntax: "proto3",
+			wantBody:   []string{"message A", "message B"},
+			wantErrs: []string{
+				`expected [;], found "option" at <input>:3:1`,
+				`expected [=], found "1" at <input>:5:12`,
+				`expected [;], found "servce" at <input>:6:1`,
+			},
+		},
+		{
+			name: "skipping a broken syntax",
+			input: `syntax = proto3;
+message A {}
+`,
+			wantBody: []string{"message A"},
+			wantErrs: []string{`expected [quote], found "proto3" at <input>:1:10`},
+		},
+		{
+			name: "skipping a declaration lacking the closing brace to EOF",
+			input: `syntax = "proto3";
+message A {}
+message B {
+  int32 b = 1;
+`,
+			wantSyntax: "proto3",
+			wantBody:   []string{"message A"},
+			wantErrs:   []string{`expected [fieldName], found EOF at <input>:5:1`},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			got, errs := p.ParseProtoRecover()
+
+			var gotSyntax string
+			if got.Syntax != nil {
+				gotSyntax = got.Syntax.ProtobufVersion
+			}
+			if gotSyntax != test.wantSyntax {
+				t.Errorf("got %q, but want %q", gotSyntax, test.wantSyntax)
+			}
+
+			var gotBody []string
+			for _, element := range got.ProtoBody {
+				switch e := element.(type) {
+				case *parser.Package:
+					gotBody = append(gotBody, "package "+e.Name)
+				case *parser.Message:
+					gotBody = append(gotBody, "message "+e.MessageName)
+				default:
+					gotBody = append(gotBody, fmt.Sprintf("%T", e))
+				}
+			}
+			if !reflect.DeepEqual(gotBody, test.wantBody) {
+				t.Errorf("got %v, but want %v", gotBody, test.wantBody)
+			}
+
+			var gotErrs []string
+			for _, err := range errs {
+				gotErrs = append(gotErrs, err.Error())
+			}
+			if !reflect.DeepEqual(gotErrs, test.wantErrs) {
+				t.Errorf("got %q, but want %q", gotErrs, test.wantErrs)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package parser
+
+import "github.com/yoheimuta/go-protoparser/v4/parser/meta"
+
+// EventKind is a kind of the Event.
+type EventKind int
+
+// EventKinds.
+const (
+	// EventNodeStarted is emitted before the parser starts to parse a node.
+	EventNodeStarted EventKind = iota
+	// EventNodeFinished is emitted after the parser finishes parsing a node.
+	EventNodeFinished
+	// EventError is emitted when the parser fails.
+	EventError
+)
+
+// Event is a parse event emitted by ParseStream.
+type Event struct {
+	Kind EventKind
+	// Pos is the position where the node starts, or where the error occurs.
+	Pos meta.Position
+	// Node is the parsed node. It is set only for EventNodeFinished.
+	Node Visitee
+	// Err is the parse error. It is set only for EventError.
+	Err error
+}
+
+// ParseStream parses the proto, emitting the events of the syntax or the edition and each top-level declaration
+// in order as it parses. It allows to render a huge file progressively.
+// The events channel is closed when the parser reaches EOF or fails. The error is emitted as an EventError, too.
+func (p *Parser) ParseStream(events chan<- Event) error {
+	defer close(events)
+
+	fail := func(err error) error {
+		events <- Event{Kind: EventError, Pos: p.lex.Pos.Position, Err: err}
+		return err
+	}
+
+	events <- Event{Kind: EventNodeStarted, Pos: p.peekPos()}
+	syntax, edition, err := p.parseSyntaxOrEdition()
+	if err != nil {
+		return fail(err)
+	}
+	if edition != nil {
+		events <- Event{Kind: EventNodeFinished, Pos: edition.Meta.Pos, Node: edition}
+	} else {
+		events <- Event{Kind: EventNodeFinished, Pos: syntax.Meta.Pos, Node: syntax}
+	}
+
+	for {
+		comments := p.ParseComments()
+		if p.IsEOF() {
+			return nil
+		}
+
+		p.lex.NextKeyword()
+		token := p.lex.Token
+		p.lex.UnNext()
+
+		pos := p.lex.Pos.Position
+		events <- Event{Kind: EventNodeStarted, Pos: pos}
+		stmt, err := p.parseProtoBodyStatement(token, comments)
+		if err != nil {
+			return fail(err)
+		}
+		p.MaybeScanInlineComment(stmt)
+		events <- Event{Kind: EventNodeFinished, Pos: pos, Node: stmt}
+	}
+}
+
+// peekPos returns the position of the next token, skipping comments.
+func (p *Parser) peekPos() meta.Position {
+	p.lex.Next()
+	defer p.lex.UnNext()
+	return p.lex.Pos.Position
+}
@@ -0,0 +1,93 @@
+package parser_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestParser_ParseStream(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantEvents []string
+		wantErr    bool
+	}{
+		{
+			name: "parsing a small file",
+			input: `// Comment.
+syntax = "proto3";
+package foo;
+message Foo {
+  int32 a = 1;
+}
+service Bar {}
+`,
+			wantEvents: []string{
+				"started at <input>:2:1",
+				"finished *parser.Syntax at <input>:2:1",
+				"started at <input>:3:1",
+				"finished *parser.Package at <input>:3:1",
+				"started at <input>:4:1",
+				"finished *parser.Message at <input>:4:1",
+				"started at <input>:7:1",
+				"finished *parser.Service at <input>:7:1",
+			},
+		},
+		{
+			name: "parsing an invalid file",
+			input: `syntax = "proto3";
+message Foo {
+  int32 a = 1
+}
+`,
+			wantEvents: []string{
+				"started at <input>:1:1",
+				"finished *parser.Syntax at <input>:1:1",
+				"started at <input>:2:1",
+				"error at <input>:4:1",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+
+			events := make(chan parser.Event)
+			errc := make(chan error, 1)
+			go func() {
+				errc <- p.ParseStream(events)
+			}()
+
+			var got []string
+			for event := range events {
+				switch event.Kind {
+				case parser.EventNodeStarted:
+					got = append(got, fmt.Sprintf("started at %s", event.Pos))
+				case parser.EventNodeFinished:
+					got = append(got, fmt.Sprintf("finished %T at %s", event.Node, event.Pos))
+				case parser.EventError:
+					got = append(got, fmt.Sprintf("error at %s", event.Pos))
+				}
+			}
+			err := <-errc
+
+			switch {
+			case test.wantErr && err == nil:
+				t.Errorf("got err nil, but want err")
+			case !test.wantErr && err != nil:
+				t.Errorf("got err %v, but want nil", err)
+			}
+			if !reflect.DeepEqual(got, test.wantEvents) {
+				t.Errorf("got %v, but want %v", got, test.wantEvents)
+			}
+		})
+	}
+}
@@ -6,8 +6,17 @@ import "github.com/yoheimuta/go-protoparser/v4/internal/lexer"
 type Parser struct {
 	lex *lexer.Lexer
 
-	permissive            bool
-	bodyIncludingComments bool
+	permissive               bool
+	bodyIncludingComments    bool
+	commentLeadingWhitespace bool
+	migrationWarnings        bool
+	autoDetectSyntax         bool
+	rawBody                  bool
+
+	// edition is the edition declared by the file being parsed, if any.
+	edition *Edition
+	// warnings are collected by ParseProto. See Warnings.
+	warnings []*MigrationWarning
 }
 
 // ConfigOption is an option for Parser.
@@ -28,6 +37,46 @@ func WithBodyIncludingComments(bodyIncludingComments bool) ConfigOption {
 	}
 }
 
+// WithCommentLeadingWhitespace is an option to capture the whitespace placed before each comment on the same line.
+// It allows to reproduce the indentation and the alignment of comments.
+func WithCommentLeadingWhitespace(commentLeadingWhitespace bool) ConfigOption {
+	return func(p *Parser) {
+		p.commentLeadingWhitespace = commentLeadingWhitespace
+	}
+}
+
+// WithMigrationWarnings is an option to collect the warnings about the proto2 constructs which don't exist in proto3.
+// The warnings are available through Warnings after ParseProto.
+func WithMigrationWarnings(migrationWarnings bool) ConfigOption {
+	return func(p *Parser) {
+		p.migrationWarnings = migrationWarnings
+	}
+}
+
+// WithAutoDetectSyntax is an option to allow a proto which lacks the syntax and the edition.
+// The protobuf version is inferred from the constructs present and recorded in Proto.InferredSyntax.
+func WithAutoDetectSyntax(autoDetectSyntax bool) ConfigOption {
+	return func(p *Parser) {
+		p.autoDetectSyntax = autoDetectSyntax
+	}
+}
+
+// WithRawBody is an option to keep the verbatim source text of each element in its Meta.Raw.
+// It trades the memory for the fidelity of a round-trip. The texts share the storage of the whole source.
+func WithRawBody(rawBody bool) ConfigOption {
+	return func(p *Parser) {
+		p.rawBody = rawBody
+	}
+}
+
+// WithInterning is an option to intern the scanned texts, so that the repeated ones like the type names
+// and the option names share the storage. It reduces the allocations and the memory held by the AST of a large proto.
+func WithInterning(interning bool) ConfigOption {
+	return func(p *Parser) {
+		p.lex.SetInterning(interning)
+	}
+}
+
 // NewParser creates a new Parser.
 func NewParser(lex *lexer.Lexer, opts ...ConfigOption) *Parser {
 	p := &Parser{
@@ -0,0 +1,80 @@
+package parser_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+// largeProto returns a proto which repeats the type names and the option names as a large schema does.
+func largeProto(messages int) string {
+	var b strings.Builder
+	b.WriteString("syntax = \"proto3\";\npackage foo.bar;\n")
+	for i := 0; i < messages; i++ {
+		fmt.Fprintf(&b, "message Message%d {\n", i)
+		b.WriteString("  option deprecated = true;\n")
+		for j := 1; j <= 10; j++ {
+			fmt.Fprintf(&b, "  string name%d = %d [json_name = \"n\", (my.option) = true];\n", j, j)
+			fmt.Fprintf(&b, "  repeated foo.bar.Message0 child%d = %d;\n", j, 10+j)
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+func dataOf(s string) uintptr {
+	return (*reflect.StringHeader)(unsafe.Pointer(&s)).Data
+}
+
+func TestWithInterning(t *testing.T) {
+	input := largeProto(2)
+
+	parse := func(opts ...parser.ConfigOption) *parser.Proto {
+		p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)), opts...)
+		proto, err := p.ParseProto()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return proto
+	}
+
+	got := parse(parser.WithInterning(true))
+	if want := parse(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, but want %v", got, want)
+	}
+
+	first := got.ProtoBody[1].(*parser.Message).MessageBody
+	second := got.ProtoBody[2].(*parser.Message).MessageBody
+	for _, pair := range [][2]string{
+		{first[1].(*parser.Field).Type, second[1].(*parser.Field).Type},
+		{first[2].(*parser.Field).Type, second[2].(*parser.Field).Type},
+		{first[0].(*parser.Option).OptionName, second[0].(*parser.Option).OptionName},
+		{first[1].(*parser.Field).FieldOptions[1].OptionName, second[1].(*parser.Field).FieldOptions[1].OptionName},
+	} {
+		if dataOf(pair[0]) != dataOf(pair[1]) {
+			t.Errorf("got %q not sharing the storage", pair[0])
+		}
+	}
+}
+
+func BenchmarkParser_ParseProto(b *testing.B) {
+	input := largeProto(1000)
+
+	for _, interning := range []bool{false, true} {
+		interning := interning
+		b.Run(fmt.Sprintf("interning=%v", interning), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)), parser.WithInterning(interning))
+				if _, err := p.ParseProto(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,352 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// indentUnit is the indentation for each nesting level.
+const indentUnit = "  "
+
+// Fprint renders the proto to the proto text, reproducing the comments and the declaration order.
+// Blank lines between declarations are kept where the source has them.
+func Fprint(w io.Writer, proto *Proto) error {
+	var nodes []Visitee
+	if proto.Syntax != nil {
+		nodes = append(nodes, proto.Syntax)
+	}
+	if proto.Edition != nil {
+		nodes = append(nodes, proto.Edition)
+	}
+	nodes = append(nodes, proto.ProtoBody...)
+
+	pr := &printer{w: w}
+	pr.body(nodes)
+	return pr.err
+}
+
+// Sprint is like Fprint but returns the proto text.
+func Sprint(proto *Proto) string {
+	var b strings.Builder
+	_ = Fprint(&b, proto)
+	return b.String()
+}
+
+// PrintNode renders a single node, such as a Message, a Field or an Enum, to the proto text at zero indentation.
+// Comments attached to the node are rendered as well.
+func PrintNode(w io.Writer, node interface{}) error {
+	pr := &printer{w: w}
+	if !pr.node(node) {
+		return fmt.Errorf("unsupported node type %T", node)
+	}
+	return pr.err
+}
+
+// printer renders nodes line by line.
+// It keeps the first error of the writer and skips the subsequent writes.
+type printer struct {
+	w      io.Writer
+	indent int
+	err    error
+}
+
+func (pr *printer) write(s string) {
+	if pr.err != nil {
+		return
+	}
+	_, pr.err = io.WriteString(pr.w, s)
+}
+
+// line writes the text at the current indentation followed by the inline comment, if any.
+func (pr *printer) line(text string, inlineComment *Comment) {
+	if text != "" {
+		pr.write(strings.Repeat(indentUnit, pr.indent) + text)
+	}
+	if inlineComment != nil {
+		separator := inlineComment.LeadingWhitespace
+		if separator == "" {
+			separator = " "
+		}
+		pr.write(separator + inlineComment.Raw)
+	}
+	pr.write("\n")
+}
+
+func (pr *printer) comments(comments []*Comment) {
+	for _, comment := range comments {
+		pr.line(comment.Raw, nil)
+	}
+}
+
+// block writes the header, the indented body and the closing curly.
+func (pr *printer) block(header string, behindLeftCurly *Comment, body []Visitee, inlineComment *Comment) {
+	pr.line(header+" {", behindLeftCurly)
+	pr.indent++
+	pr.body(body)
+	pr.indent--
+	pr.line("}", inlineComment)
+}
+
+// body writes the elements, separating them with a blank line where the source has one.
+func (pr *printer) body(elements []Visitee) {
+	for i, element := range elements {
+		if 0 < i && isSeparatedByBlankLine(elements[i-1], element) {
+			pr.write("\n")
+		}
+		pr.node(element)
+	}
+}
+
+// isSeparatedByBlankLine reports whether a blank line lies between the elements in the source.
+// It returns false when either position is unknown, such as for an element built by hand.
+func isSeparatedByBlankLine(prev, next Visitee) bool {
+	_, prevLast := lineRange(prev)
+	nextFirst, _ := lineRange(next)
+	if prevLast == 0 || nextFirst == 0 {
+		return false
+	}
+	return prevLast+1 < nextFirst
+}
+
+// lineRange returns the first line, including the leading comments, and the last line of the element.
+func lineRange(element Visitee) (first int, last int) {
+	if comment, ok := element.(*Comment); ok {
+		return comment.Meta.Pos.Line, comment.Meta.LastPos.Line
+	}
+
+	span, ok := spanOf(element)
+	if !ok {
+		return 0, 0
+	}
+	first = span.meta.Pos.Line
+	if 0 < len(span.comments) {
+		first = span.comments[0].Meta.Pos.Line
+	}
+	return first, span.meta.LastPos.Line
+}
+
+// node writes the node. It returns false when the type of the node is not supported.
+func (pr *printer) node(node interface{}) bool {
+	switch n := node.(type) {
+	case *Comment:
+		pr.line(n.Raw, nil)
+	case *EmptyStatement:
+		pr.line(";", n.InlineComment)
+	case *Syntax:
+		pr.comments(n.Comments)
+		pr.line(fmt.Sprintf("syntax = %q;", n.ProtobufVersion), n.InlineComment)
+	case *Edition:
+		pr.comments(n.Comments)
+		pr.line(fmt.Sprintf("edition = %q;", n.Edition), n.InlineComment)
+	case *Import:
+		pr.comments(n.Comments)
+		pr.line("import "+importModifierText(n.Modifier)+n.Location+";", n.InlineComment)
+	case *Package:
+		pr.comments(n.Comments)
+		pr.line("package "+n.Name+";", n.InlineComment)
+	case *Option:
+		pr.comments(n.Comments)
+		pr.line("option "+n.OptionName+" = "+pr.constantText(n.Constant)+";", n.InlineComment)
+	case *Message:
+		pr.comments(n.Comments)
+		pr.block("message "+n.MessageName, n.InlineCommentBehindLeftCurly, n.MessageBody, n.InlineComment)
+	case *Enum:
+		pr.comments(n.Comments)
+		pr.block("enum "+n.EnumName, n.InlineCommentBehindLeftCurly, n.EnumBody, n.InlineComment)
+	case *EnumField:
+		var options []string
+		for _, option := range n.EnumValueOptions {
+			options = append(options, option.OptionName+" = "+pr.constantText(option.Constant))
+		}
+		pr.comments(n.Comments)
+		pr.line(n.Ident+" = "+n.Number+bracketedOptionsText(options)+";", n.InlineComment)
+	case *Service:
+		pr.comments(n.Comments)
+		pr.block("service "+n.ServiceName, n.InlineCommentBehindLeftCurly, n.ServiceBody, n.InlineComment)
+	case *RPC:
+		header := fmt.Sprintf(
+			"rpc %s(%s%s) returns (%s%s)",
+			n.RPCName,
+			streamText(n.RPCRequest.IsStream),
+			n.RPCRequest.MessageType,
+			streamText(n.RPCResponse.IsStream),
+			n.RPCResponse.MessageType,
+		)
+		pr.comments(n.Comments)
+		if len(n.Options) == 0 {
+			pr.line(header+";", n.InlineComment)
+			break
+		}
+		var body []Visitee
+		for _, option := range n.Options {
+			body = append(body, option)
+		}
+		pr.block(header, nil, body, n.InlineComment)
+	case *Extend:
+		pr.comments(n.Comments)
+		pr.block("extend "+n.MessageType, n.InlineCommentBehindLeftCurly, n.ExtendBody, n.InlineComment)
+	case *Field:
+		text := fieldLabelText(n.IsRepeated, n.IsRequired, n.IsOptional) +
+			n.Type + " " + n.FieldName + " = " + n.FieldNumber + pr.fieldOptionsText(n.FieldOptions) + ";"
+		pr.comments(n.Comments)
+		pr.line(text, n.InlineComment)
+	case *MapField:
+		text := "map<" + n.KeyType + ", " + n.Type + "> " +
+			n.MapName + " = " + n.FieldNumber + pr.fieldOptionsText(n.FieldOptions) + ";"
+		pr.comments(n.Comments)
+		pr.line(text, n.InlineComment)
+	case *GroupField:
+		header := fieldLabelText(n.IsRepeated, n.IsRequired, n.IsOptional) +
+			"group " + n.GroupName + " = " + n.FieldNumber
+		pr.comments(n.Comments)
+		pr.block(header, n.InlineCommentBehindLeftCurly, n.MessageBody, n.InlineComment)
+	case *Oneof:
+		pr.comments(n.Comments)
+		pr.block("oneof "+n.OneofName, n.InlineCommentBehindLeftCurly, oneofBody(n), n.InlineComment)
+	case *OneofField:
+		text := n.Type + " " + n.FieldName + " = " + n.FieldNumber + pr.fieldOptionsText(n.FieldOptions) + ";"
+		pr.comments(n.Comments)
+		pr.line(text, n.InlineComment)
+	case *Reserved:
+		items := n.FieldNames
+		if len(items) == 0 {
+			items = rangesText(n.Ranges)
+		}
+		pr.comments(n.Comments)
+		pr.line("reserved "+pr.listText(items, n.Multiline)+";", n.InlineComment)
+	case *Extensions:
+		pr.comments(n.Comments)
+		pr.line("extensions "+pr.listText(rangesText(n.Ranges), n.Multiline)+";", n.InlineComment)
+	default:
+		return false
+	}
+	return true
+}
+
+// listText joins the items with commas.
+// When multiline is true, each item after the first is placed on its own line indented one more level.
+func (pr *printer) listText(items []string, multiline bool) string {
+	separator := ", "
+	if multiline {
+		separator = ",\n" + strings.Repeat(indentUnit, pr.indent+1)
+	}
+	return strings.Join(items, separator)
+}
+
+// oneofBody returns the fields and the options of the oneof in the order of their positions.
+func oneofBody(oneof *Oneof) []Visitee {
+	var body []Visitee
+	for _, field := range oneof.OneofFields {
+		body = append(body, field)
+	}
+	for _, option := range oneof.Options {
+		body = append(body, option)
+	}
+	sort.SliceStable(body, func(i, j int) bool {
+		a, _ := spanOf(body[i])
+		b, _ := spanOf(body[j])
+		return isBefore(a.meta.Pos, b.meta.Pos)
+	})
+	return body
+}
+
+func importModifierText(modifier ImportModifier) string {
+	switch modifier {
+	case ImportModifierPublic:
+		return "public "
+	case ImportModifierWeak:
+		return "weak "
+	default:
+		return ""
+	}
+}
+
+func fieldLabelText(isRepeated, isRequired, isOptional bool) string {
+	switch {
+	case isRepeated:
+		return "repeated "
+	case isRequired:
+		return "required "
+	case isOptional:
+		return "optional "
+	default:
+		return ""
+	}
+}
+
+func streamText(isStream bool) string {
+	if isStream {
+		return "stream "
+	}
+	return ""
+}
+
+func (pr *printer) fieldOptionsText(fieldOptions []*FieldOption) string {
+	var options []string
+	for _, option := range fieldOptions {
+		options = append(options, option.OptionName+" = "+pr.constantText(option.Constant))
+	}
+	return bracketedOptionsText(options)
+}
+
+// constantText returns the constant as written, except that an aggregate is rendered from its structured form
+// with a field per line, indented one more level than the current one.
+// A list is rendered as the repeated fields, which is equivalent.
+func (pr *printer) constantText(constant string) string {
+	if !strings.HasPrefix(constant, "{") {
+		return constant
+	}
+	structured, err := ParseOptionConstant(constant)
+	if err != nil || !structured.IsAggregate() {
+		return constant
+	}
+	return aggregateText(structured, pr.indent)
+}
+
+// aggregateText renders the aggregate whose closing curly is at the indent level.
+func aggregateText(aggregate *OptionConstant, indent int) string {
+	if len(aggregate.Names) == 0 {
+		return "{}"
+	}
+
+	prefix := strings.Repeat(indentUnit, indent+1)
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, name := range aggregate.Names {
+		values := aggregate.Fields[name]
+		if len(values) == 0 {
+			b.WriteString(prefix + name + ": []\n")
+			continue
+		}
+		for _, value := range values {
+			text := value.Scalar
+			if value.IsAggregate() {
+				text = aggregateText(value, indent+1)
+			}
+			b.WriteString(prefix + name + ": " + text + "\n")
+		}
+	}
+	b.WriteString(strings.Repeat(indentUnit, indent) + "}")
+	return b.String()
+}
+
+func bracketedOptionsText(options []string) string {
+	if len(options) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(options, ", ") + "]"
+}
+
+func rangesText(ranges []*Range) []string {
+	var texts []string
+	for _, r := range ranges {
+		text := r.Begin
+		if r.End != "" {
+			text += " to " + r.End
+		}
+		texts = append(texts, text)
+	}
+	return texts
+}
@@ -0,0 +1,337 @@
+package parser_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestPrintNode(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputNode interface{}
+		wantText  string
+		wantErr   bool
+	}{
+		{
+			name: "printing a field",
+			inputNode: &parser.Field{
+				IsRepeated:  true,
+				Type:        "string",
+				FieldName:   "snippets",
+				FieldNumber: "3",
+				FieldOptions: []*parser.FieldOption{
+					{
+						OptionName: "packed",
+						Constant:   "true",
+					},
+					{
+						OptionName: "(validator.field)",
+						Constant:   "{msg_exists:true}",
+					},
+				},
+				Comments: []*parser.Comment{
+					{
+						Raw: "// snippets are the found texts.",
+					},
+				},
+				InlineComment: &parser.Comment{
+					Raw: "// deprecated",
+				},
+			},
+			wantText: `// snippets are the found texts.
+repeated string snippets = 3 [packed = true, (validator.field) = {
+  msg_exists: true
+}]; // deprecated
+`,
+		},
+		{
+			name: "printing an enum",
+			inputNode: &parser.Enum{
+				EnumName: "EnumAllowingAlias",
+				EnumBody: []parser.Visitee{
+					&parser.Option{
+						OptionName: "allow_alias",
+						Constant:   "true",
+					},
+					&parser.EnumField{
+						Ident:  "UNKNOWN",
+						Number: "0",
+					},
+					&parser.EnumField{
+						Ident:  "RUNNING",
+						Number: "2",
+						EnumValueOptions: []*parser.EnumValueOption{
+							{
+								OptionName: "(custom_option)",
+								Constant:   `"hello world"`,
+							},
+						},
+					},
+				},
+			},
+			wantText: `enum EnumAllowingAlias {
+  option allow_alias = true;
+  UNKNOWN = 0;
+  RUNNING = 2 [(custom_option) = "hello world"];
+}
+`,
+		},
+		{
+			name: "printing a rpc with options",
+			inputNode: &parser.RPC{
+				RPCName: "Search",
+				RPCRequest: &parser.RPCRequest{
+					MessageType: "SearchRequest",
+				},
+				RPCResponse: &parser.RPCResponse{
+					IsStream:    true,
+					MessageType: "SearchResponse",
+				},
+				Options: []*parser.Option{
+					{
+						OptionName: "deprecated",
+						Constant:   "true",
+					},
+				},
+			},
+			wantText: `rpc Search(SearchRequest) returns (stream SearchResponse) {
+  option deprecated = true;
+}
+`,
+		},
+		{
+			name:      "printing an unsupported node",
+			inputNode: &parser.Proto{},
+			wantErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := parser.PrintNode(&buf, test.inputNode)
+			switch {
+			case test.wantErr:
+				if err == nil {
+					t.Errorf("got err nil, but want err")
+				}
+				return
+			case !test.wantErr && err != nil:
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			if buf.String() != test.wantText {
+				t.Errorf("got %q, but want %q", buf.String(), test.wantText)
+			}
+		})
+	}
+}
+
+func TestPrintNode_parsedMessage(t *testing.T) {
+	input := `message Outer { // Level 1
+  option (my_option).a = true;
+  message Inner {
+    required int64 ival = 1;
+  } // Inner
+  repeated Inner inner_message = 2;
+  map<int32, string> my_map = 3;
+  oneof test_oneof {
+    option (oneof_option) = true;
+    string name = 4;
+    /* The sub message. */
+    Inner sub_message = 5 [deprecated = true];
+  }
+  optional group Result = 6 {
+    optional string url = 7;
+  }
+  reserved 8, 9 to 11;
+  reserved "foo",
+    "bar";
+  extensions 100 to max;
+}
+`
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)), parser.WithPermissive(true))
+	msg, err := p.ParseMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	err = parser.PrintNode(&buf, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != input {
+		t.Errorf("got %s, but want %s", buf.String(), input)
+	}
+}
+
+func TestFprint(t *testing.T) {
+	tests := []struct {
+		name                       string
+		input                      string
+		inputBodyIncludingComments bool
+		wantOutput                 string
+	}{
+		{
+			name: "printing an excerpt from the official reference",
+			input: `syntax = "proto3";
+// An example of the official reference
+package examplepb;
+
+import public "other.proto";
+option java_package = "com.example.foo";
+
+enum EnumAllowingAlias {
+  option allow_alias = true;
+  UNKNOWN = 0;
+  RUNNING = 2 [(custom_option) = "hello world"];
+}
+
+/* outer is a message. */
+message outer {
+  option (my_option).a = true;
+  message inner { // Level 2
+    int64 ival = 1;
+  }
+
+  repeated inner inner_message = 2;
+  EnumAllowingAlias enum_field = 3;
+  map<int32, string> my_map = 4; // a map
+}
+
+service HelloService {
+  rpc SayHello(HelloRequest) returns (stream HelloResponse) {
+    option (google.api.http) = {
+      get: "/v1/hello"
+    };
+  }
+}
+`,
+		},
+		{
+			name: "printing a proto2 file with an edition-free syntax and trailing comments",
+			input: `syntax = "proto2";
+message Foo {
+  required int32 a = 1;
+  optional group Result = 2 {
+    repeated string url = 3;
+  }
+  extensions 100 to 199;
+  // a trailing comment
+}
+extend Foo {
+  optional int32 bar = 126;
+}
+// a last comment
+`,
+			inputBodyIncludingComments: true,
+		},
+		{
+			name: "printing multi-line aggregates with the current indentation",
+			input: `syntax = "proto3";
+option (x) = {a:1
+b:{c:"d"}};
+message Foo {
+  option (z) = {e: [] f: [1, 2]
+  };
+  string bar = 1 [(y) = {k:1
+v:2}];
+}
+`,
+			wantOutput: `syntax = "proto3";
+option (x) = {
+  a: 1
+  b: {
+    c: "d"
+  }
+};
+message Foo {
+  option (z) = {
+    e: []
+    f: 1
+    f: 2
+  };
+  string bar = 1 [(y) = {
+    k: 1
+    v: 2
+  }];
+}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(
+				lexer.NewLexer(strings.NewReader(test.input)),
+				parser.WithPermissive(true),
+				parser.WithBodyIncludingComments(test.inputBodyIncludingComments),
+			)
+			proto, err := p.ParseProto()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := test.input
+			if test.wantOutput != "" {
+				want = test.wantOutput
+			}
+			got := parser.Sprint(proto)
+			if got != want {
+				t.Errorf("got %s, but want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestFprint_roundTrip(t *testing.T) {
+	paths, err := filepath.Glob("../_testdata/*.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parse := func(input string) *parser.Proto {
+		p := parser.NewParser(
+			lexer.NewLexer(strings.NewReader(input)),
+			parser.WithPermissive(true),
+			parser.WithBodyIncludingComments(true),
+		)
+		proto, err := p.ParseProto()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return proto
+	}
+
+	for _, path := range paths {
+		path := path
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			err = parser.Fprint(&buf, parse(string(content)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			printed := buf.String()
+
+			reprinted := parser.Sprint(parse(printed))
+			if reprinted != printed {
+				t.Errorf("got %s, but want %s", reprinted, printed)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package parser
 
-import "github.com/yoheimuta/go-protoparser/v4/internal/lexer/scanner"
+import (
+	"context"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer/scanner"
+)
 
 // ProtoMeta represents a meta information about the Proto.
 type ProtoMeta struct {
@@ -11,6 +15,11 @@ type ProtoMeta struct {
 // Proto represents a protocol buffer definition.
 type Proto struct {
 	Syntax *Syntax
+	// Edition is set in place of Syntax when the file declares an edition.
+	Edition *Edition
+	// InferredSyntax is the protobuf version inferred when the file declares neither syntax nor edition.
+	// It is set only when the parser is configured with WithAutoDetectSyntax.
+	InferredSyntax string
 	// ProtoBody is a slice of sum type consisted of *Import, *Package, *Option, *Message, *Enum, *Service, *Extend and *EmptyStatement.
 	ProtoBody []Visitee
 	Meta      *ProtoMeta
@@ -21,46 +30,166 @@ func (p *Proto) Accept(v Visitor) {
 	if p.Syntax != nil {
 		p.Syntax.Accept(v)
 	}
+	if p.Edition != nil {
+		p.Edition.Accept(v)
+	}
 
 	for _, body := range p.ProtoBody {
 		body.Accept(v)
 	}
 }
 
+// PackageName returns the name of the package declared in the proto.
+// It returns an empty string when the package declaration is absent,
+// in which case the top-level definitions belong to the empty package.
+func (p *Proto) PackageName() string {
+	for _, body := range p.ProtoBody {
+		if pkg, ok := body.(*Package); ok {
+			return pkg.Name
+		}
+	}
+	return ""
+}
+
+// Imports returns the imports declared in the proto in order.
+func (p *Proto) Imports() []*Import {
+	var imports []*Import
+	for _, body := range p.ProtoBody {
+		if i, ok := body.(*Import); ok {
+			imports = append(imports, i)
+		}
+	}
+	return imports
+}
+
+// FileOption returns the first file-level option which has the given name, such as "java_package" or "(my_option)".
+func (p *Proto) FileOption(name string) (*Option, bool) {
+	for _, body := range p.ProtoBody {
+		if option, ok := body.(*Option); ok && option.OptionName == name {
+			return option, true
+		}
+	}
+	return nil, false
+}
+
+// BoolFileOption returns the value of the boolean file-level option which has the given name, such as "cc_enable_arenas".
+// set is false when the option is absent or its constant isn't a canonical boolean.
+func (p *Proto) BoolFileOption(name string) (value bool, set bool) {
+	option, ok := p.FileOption(name)
+	if !ok {
+		return false, false
+	}
+	return option.BoolValue()
+}
+
 // ParseProto parses the proto.
-//  proto = syntax { import | package | option | topLevelDef | emptyStatement }
+// A parse error is returned as *Error, which carries the text of the offending line.
+//  proto = ( syntax | edition ) { import | package | option | topLevelDef | emptyStatement }
 //
-// See https://developers.google.com/protocol-buffers/docs/reference/proto3-spec#proto_file
+// See
+//  https://developers.google.com/protocol-buffers/docs/reference/proto3-spec#proto_file
+//  https://protobuf.dev/reference/protobuf/edition-2023-spec/#proto_file
 func (p *Parser) ParseProto() (*Proto, error) {
-	syntaxComments := p.ParseComments()
-	syntax, err := p.ParseSyntax()
-	if err != nil {
+	return p.ParseProtoContext(context.Background())
+}
+
+// ParseProtoContext is like ParseProto but aborts with the error of the context once it's done.
+// The context is checked before each top-level declaration, so a huge file can be parsed under a timeout.
+func (p *Parser) ParseProtoContext(ctx context.Context) (*Proto, error) {
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
-	syntax.Comments = syntaxComments
-	p.MaybeScanInlineComment(syntax)
 
-	protoBody, err := p.parseProtoBody()
+	syntax, edition, err := p.parseSyntaxOrEdition()
 	if err != nil {
-		return nil, err
+		return nil, p.withLineText(err)
 	}
 
-	return &Proto{
+	protoBody, err := p.parseProtoBody(ctx, nil)
+	if err != nil {
+		return nil, p.withLineText(err)
+	}
+	return p.newProto(syntax, edition, protoBody), nil
+}
+
+// newProto creates the Proto parsed to the end.
+func (p *Parser) newProto(syntax *Syntax, edition *Edition, protoBody []Visitee) *Proto {
+	proto := &Proto{
 		Syntax:    syntax,
+		Edition:   edition,
 		ProtoBody: protoBody,
 		Meta: &ProtoMeta{
 			Filename: p.lex.Pos.Filename,
 		},
-	}, nil
+	}
+	if syntax == nil && edition == nil {
+		proto.InferredSyntax = inferSyntax(protoBody)
+	}
+	var warnings []*MigrationWarning
+	if p.migrationWarnings {
+		warnings = migrationWarnings(proto)
+	}
+	if !p.permissive {
+		warnings = append(warnings, boolSpellingWarnings(proto)...)
+	}
+	p.warnings = warnings
+	if p.rawBody {
+		proto.Accept(&rawBodySetter{source: p.lex.Source()})
+	}
+	return proto
+}
+
+// parseSyntaxOrEdition parses the syntax or the edition which a proto begins with.
+// Both are absent only when the parser is configured with WithAutoDetectSyntax.
+func (p *Parser) parseSyntaxOrEdition() (*Syntax, *Edition, error) {
+	p.lex.NextKeyword()
+	token := p.lex.Token
+	p.lex.UnNext()
+	if p.autoDetectSyntax && token != scanner.TSYNTAX && token != scanner.TEDITION {
+		return nil, nil, nil
+	}
+
+	comments := p.ParseComments()
+
+	var syntax *Syntax
+	var edition *Edition
+	switch token {
+	case scanner.TEDITION:
+		var err error
+		edition, err = p.ParseEdition()
+		if err != nil {
+			return nil, nil, err
+		}
+		edition.Comments = comments
+		p.MaybeScanInlineComment(edition)
+		p.edition = edition
+	default:
+		var err error
+		syntax, err = p.ParseSyntax()
+		if err != nil {
+			return nil, nil, err
+		}
+		syntax.Comments = comments
+		p.MaybeScanInlineComment(syntax)
+	}
+
+	return syntax, edition, nil
 }
 
 // protoBody = { import | package | option | topLevelDef | emptyStatement }
 // topLevelDef = message | enum | service | extend
 // See https://developers.google.com/protocol-buffers/docs/reference/proto3-spec#proto_file
-func (p *Parser) parseProtoBody() ([]Visitee, error) {
+//
+// When onError is given, a statement which fails to parse is passed to it along with the position where
+// the statement begins, instead of aborting the parsing.
+func (p *Parser) parseProtoBody(ctx context.Context, onError func(err error, start scanner.Position)) ([]Visitee, error) {
 	var protoBody []Visitee
 
 	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		comments := p.ParseComments()
 
 		if p.IsEOF() {
@@ -74,72 +203,99 @@ func (p *Parser) parseProtoBody() ([]Visitee, error) {
 
 		p.lex.NextKeyword()
 		token := p.lex.Token
+		start := p.lex.Pos
 		p.lex.UnNext()
-
-		var stmt interface {
-			HasInlineCommentSetter
-			Visitee
+		// The errors are reported within the statement, so the preceding text is no longer needed
+		// unless it's kept for the raw body.
+		if !p.rawBody {
+			p.lex.Release(start)
 		}
 
-		switch token {
-		case scanner.TIMPORT:
-			importValue, err := p.ParseImport()
-			if err != nil {
-				return nil, err
-			}
-			importValue.Comments = comments
-			stmt = importValue
-		case scanner.TPACKAGE:
-			packageValue, err := p.ParsePackage()
-			if err != nil {
-				return nil, err
-			}
-			packageValue.Comments = comments
-			stmt = packageValue
-		case scanner.TOPTION:
-			option, err := p.ParseOption()
-			if err != nil {
-				return nil, err
-			}
-			option.Comments = comments
-			stmt = option
-		case scanner.TMESSAGE:
-			message, err := p.ParseMessage()
-			if err != nil {
-				return nil, err
-			}
-			message.Comments = comments
-			stmt = message
-		case scanner.TENUM:
-			enum, err := p.ParseEnum()
-			if err != nil {
-				return nil, err
-			}
-			enum.Comments = comments
-			stmt = enum
-		case scanner.TSERVICE:
-			service, err := p.ParseService()
-			if err != nil {
-				return nil, err
-			}
-			service.Comments = comments
-			stmt = service
-		case scanner.TEXTEND:
-			extend, err := p.ParseExtend()
-			if err != nil {
-				return nil, err
-			}
-			extend.Comments = comments
-			stmt = extend
-		default:
-			err := p.lex.ReadEmptyStatement()
-			if err != nil {
+		stmt, err := p.parseProtoBodyStatement(token, comments)
+		if err != nil {
+			if onError == nil {
 				return nil, err
 			}
-			protoBody = append(protoBody, &EmptyStatement{})
+			onError(err, start)
+			continue
 		}
 
 		p.MaybeScanInlineComment(stmt)
 		protoBody = append(protoBody, stmt)
 	}
 }
+
+// protoBodyStatement is a statement of the protoBody.
+type protoBodyStatement interface {
+	HasInlineCommentSetter
+	Visitee
+}
+
+// parseProtoBodyStatement parses the statement which starts with the token.
+func (p *Parser) parseProtoBodyStatement(token scanner.Token, comments []*Comment) (protoBodyStatement, error) {
+	var stmt protoBodyStatement
+
+	switch token {
+	case scanner.TIMPORT:
+		importValue, err := p.ParseImport()
+		if err != nil {
+			return nil, err
+		}
+		importValue.Comments = comments
+		stmt = importValue
+	case scanner.TPACKAGE:
+		packageValue, err := p.ParsePackage()
+		if err != nil {
+			return nil, err
+		}
+		packageValue.Comments = comments
+		stmt = packageValue
+	case scanner.TOPTION:
+		option, err := p.ParseOption()
+		if err != nil {
+			return nil, err
+		}
+		option.Comments = comments
+		stmt = option
+	case scanner.TMESSAGE:
+		message, err := p.ParseMessage()
+		if err != nil {
+			return nil, err
+		}
+		message.Comments = comments
+		stmt = message
+	case scanner.TENUM:
+		enum, err := p.ParseEnum()
+		if err != nil {
+			return nil, err
+		}
+		enum.Comments = comments
+		stmt = enum
+	case scanner.TSERVICE:
+		service, err := p.ParseService()
+		if err != nil {
+			return nil, err
+		}
+		service.Comments = comments
+		stmt = service
+	case scanner.TEXTEND:
+		extend, err := p.ParseExtend()
+		if err != nil {
+			return nil, err
+		}
+		extend.Comments = comments
+		stmt = extend
+	case scanner.TSYNTAX, scanner.TEDITION:
+		// A proto declares either a syntax or an edition, only once at the beginning.
+		p.lex.NextKeyword()
+		return nil, p.unexpected("a single syntax or edition declaration at the beginning of the file")
+	default:
+		err := p.lex.ReadEmptyStatement()
+		if err != nil {
+			return nil, err
+		}
+		stmt = newEmptyStatement(p.lex.Pos)
+	}
+
+	return stmt, nil
+}
@@ -20,6 +20,11 @@ func (p *protoTestVisitor) VisitComment(c *parser.Comment) {
 	p.buffers = append(p.buffers, "Comment: "+c.Raw)
 }
 
+func (p *protoTestVisitor) VisitEdition(e *parser.Edition) bool {
+	p.buffers = append(p.buffers, "Edition: "+e.Edition)
+	return true
+}
+
 func (p *protoTestVisitor) VisitEmptyStatement(*parser.EmptyStatement) bool {
 	p.buffers = append(p.buffers, "EmptyStatement")
 	return true
@@ -129,6 +134,15 @@ func TestProto_Accept(t *testing.T) {
 			},
 			wantBuffer: `Syntax: 3`,
 		},
+		{
+			name: "parsing an edition",
+			inputProto: &parser.Proto{
+				Edition: &parser.Edition{
+					Edition: "2023",
+				},
+			},
+			wantBuffer: `Edition: 2023`,
+		},
 		{
 			name: "parsing an enum",
 			inputProto: &parser.Proto{
@@ -1,6 +1,9 @@
 package parser_test
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"reflect"
 	"strings"
 	"testing"
@@ -17,6 +20,7 @@ func TestParser_ParseProto(t *testing.T) {
 		input                      string
 		filename                   string
 		inputBodyIncludingComments bool
+		permissive                 bool
 		wantProto                  *parser.Proto
 		wantErr                    bool
 	}{
@@ -57,6 +61,12 @@ message outer {
 							Line:     2,
 							Column:   1,
 						},
+						LastPos: meta.Position{
+							Filename: "official.proto",
+							Offset:   18,
+							Line:     2,
+							Column:   18,
+						},
 					},
 				},
 				ProtoBody: []parser.Visitee{
@@ -70,6 +80,12 @@ message outer {
 								Line:     3,
 								Column:   1,
 							},
+							LastPos: meta.Position{
+								Filename: "official.proto",
+								Offset:   47,
+								Line:     3,
+								Column:   28,
+							},
 						},
 					},
 					&parser.Option{
@@ -82,6 +98,12 @@ message outer {
 								Line:     4,
 								Column:   1,
 							},
+							LastPos: meta.Position{
+								Filename: "official.proto",
+								Offset:   88,
+								Line:     4,
+								Column:   40,
+							},
 						},
 					},
 					&parser.Enum{
@@ -97,6 +119,12 @@ message outer {
 										Line:     6,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "official.proto",
+										Offset:   142,
+										Line:     6,
+										Column:   28,
+									},
 								},
 							},
 							&parser.EnumField{
@@ -109,6 +137,12 @@ message outer {
 										Line:     7,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "official.proto",
+										Offset:   157,
+										Line:     7,
+										Column:   14,
+									},
 								},
 							},
 							&parser.EnumField{
@@ -121,6 +155,12 @@ message outer {
 										Line:     8,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "official.proto",
+										Offset:   172,
+										Line:     8,
+										Column:   14,
+									},
 								},
 							},
 							&parser.EnumField{
@@ -139,6 +179,12 @@ message outer {
 										Line:     9,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "official.proto",
+										Offset:   221,
+										Line:     9,
+										Column:   48,
+									},
 								},
 							},
 						},
@@ -170,6 +216,12 @@ message outer {
 										Line:     12,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "official.proto",
+										Offset:   270,
+										Line:     12,
+										Column:   30,
+									},
 								},
 							},
 							&parser.Message{
@@ -186,6 +238,12 @@ message outer {
 												Line:     14,
 												Column:   5,
 											},
+											LastPos: meta.Position{
+												Filename: "official.proto",
+												Offset:   308,
+												Line:     14,
+												Column:   19,
+											},
 										},
 									},
 								},
@@ -216,6 +274,12 @@ message outer {
 										Line:     16,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "official.proto",
+										Offset:   348,
+										Line:     16,
+										Column:   35,
+									},
 								},
 							},
 							&parser.Field{
@@ -229,6 +293,12 @@ message outer {
 										Line:     17,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "official.proto",
+										Offset:   383,
+										Line:     17,
+										Column:   34,
+									},
 								},
 							},
 							&parser.MapField{
@@ -243,6 +313,12 @@ message outer {
 										Line:     18,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "official.proto",
+										Offset:   416,
+										Line:     18,
+										Column:   32,
+									},
 								},
 							},
 						},
@@ -286,6 +362,12 @@ service SearchService {
 							Line:     2,
 							Column:   1,
 						},
+						LastPos: meta.Position{
+							Filename: "service.proto",
+							Offset:   18,
+							Line:     2,
+							Column:   18,
+						},
 					},
 				},
 				ProtoBody: []parser.Visitee{
@@ -303,6 +385,12 @@ service SearchService {
 											Line:     4,
 											Column:   14,
 										},
+										LastPos: meta.Position{
+											Filename: "service.proto",
+											Offset:   71,
+											Line:     4,
+											Column:   28,
+										},
 									},
 								},
 								RPCResponse: &parser.RPCResponse{
@@ -314,6 +402,12 @@ service SearchService {
 											Line:     4,
 											Column:   38,
 										},
+										LastPos: meta.Position{
+											Filename: "service.proto",
+											Offset:   96,
+											Line:     4,
+											Column:   53,
+										},
 									},
 								},
 								Meta: meta.Meta{
@@ -393,6 +487,12 @@ service SearchService {
 									Line:     2,
 									Column:   1,
 								},
+								LastPos: meta.Position{
+									Filename: "comments.proto",
+									Offset:   9,
+									Line:     2,
+									Column:   9,
+								},
 							},
 						},
 						{
@@ -406,6 +506,12 @@ syntax2
 									Line:     3,
 									Column:   1,
 								},
+								LastPos: meta.Position{
+									Filename: "comments.proto",
+									Offset:   23,
+									Line:     5,
+									Column:   2,
+								},
 							},
 						},
 					},
@@ -416,6 +522,12 @@ syntax2
 							Line:     6,
 							Column:   1,
 						},
+						LastPos: meta.Position{
+							Filename: "comments.proto",
+							Offset:   42,
+							Line:     6,
+							Column:   18,
+						},
 					},
 				},
 				ProtoBody: []parser.Visitee{
@@ -432,6 +544,12 @@ syntax2
 										Line:     7,
 										Column:   1,
 									},
+									LastPos: meta.Position{
+										Filename: "comments.proto",
+										Offset:   52,
+										Line:     7,
+										Column:   9,
+									},
 								},
 							},
 						},
@@ -442,6 +560,12 @@ syntax2
 								Line:     8,
 								Column:   1,
 							},
+							LastPos: meta.Position{
+								Filename: "comments.proto",
+								Offset:   81,
+								Line:     8,
+								Column:   28,
+							},
 						},
 					},
 					&parser.Package{
@@ -456,6 +580,12 @@ syntax2
 										Line:     9,
 										Column:   1,
 									},
+									LastPos: meta.Position{
+										Filename: "comments.proto",
+										Offset:   95,
+										Line:     9,
+										Column:   13,
+									},
 								},
 							},
 						},
@@ -466,6 +596,12 @@ syntax2
 								Line:     10,
 								Column:   1,
 							},
+							LastPos: meta.Position{
+								Filename: "comments.proto",
+								Offset:   112,
+								Line:     10,
+								Column:   16,
+							},
 						},
 					},
 					&parser.Option{
@@ -481,6 +617,12 @@ syntax2
 										Line:     11,
 										Column:   1,
 									},
+									LastPos: meta.Position{
+										Filename: "comments.proto",
+										Offset:   122,
+										Line:     11,
+										Column:   9,
+									},
 								},
 							},
 						},
@@ -491,6 +633,12 @@ syntax2
 								Line:     12,
 								Column:   1,
 							},
+							LastPos: meta.Position{
+								Filename: "comments.proto",
+								Offset:   163,
+								Line:     12,
+								Column:   40,
+							},
 						},
 					},
 					&parser.Message{
@@ -505,6 +653,12 @@ syntax2
 										Line:     13,
 										Column:   1,
 									},
+									LastPos: meta.Position{
+										Filename: "comments.proto",
+										Offset:   174,
+										Line:     13,
+										Column:   10,
+									},
 								},
 							},
 						},
@@ -536,6 +690,12 @@ syntax2
 										Line:     18,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "comments.proto",
+										Offset:   254,
+										Line:     18,
+										Column:   28,
+									},
 								},
 							},
 						},
@@ -549,6 +709,12 @@ syntax2
 										Line:     16,
 										Column:   1,
 									},
+									LastPos: meta.Position{
+										Filename: "comments.proto",
+										Offset:   200,
+										Line:     16,
+										Column:   7,
+									},
 								},
 							},
 						},
@@ -581,6 +747,12 @@ syntax2
 											Line:     22,
 											Column:   14,
 										},
+										LastPos: meta.Position{
+											Filename: "comments.proto",
+											Offset:   320,
+											Line:     22,
+											Column:   28,
+										},
 									},
 								},
 								RPCResponse: &parser.RPCResponse{
@@ -592,6 +764,12 @@ syntax2
 											Line:     22,
 											Column:   38,
 										},
+										LastPos: meta.Position{
+											Filename: "comments.proto",
+											Offset:   345,
+											Line:     22,
+											Column:   53,
+										},
 									},
 								},
 								Meta: meta.Meta{
@@ -620,6 +798,12 @@ syntax2
 										Line:     20,
 										Column:   1,
 									},
+									LastPos: meta.Position{
+										Filename: "comments.proto",
+										Offset:   267,
+										Line:     20,
+										Column:   10,
+									},
 								},
 							},
 						},
@@ -673,6 +857,12 @@ service SearchService {
 								Line:     2,
 								Column:   20,
 							},
+							LastPos: meta.Position{
+								Filename: "inlineComments.proto",
+								Offset:   28,
+								Line:     2,
+								Column:   28,
+							},
 						},
 					},
 					Meta: meta.Meta{
@@ -682,6 +872,12 @@ service SearchService {
 							Line:     2,
 							Column:   1,
 						},
+						LastPos: meta.Position{
+							Filename: "inlineComments.proto",
+							Offset:   18,
+							Line:     2,
+							Column:   18,
+						},
 					},
 				},
 				ProtoBody: []parser.Visitee{
@@ -697,6 +893,12 @@ service SearchService {
 									Line:     3,
 									Column:   30,
 								},
+								LastPos: meta.Position{
+									Filename: "inlineComments.proto",
+									Offset:   67,
+									Line:     3,
+									Column:   38,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -706,6 +908,12 @@ service SearchService {
 								Line:     3,
 								Column:   1,
 							},
+							LastPos: meta.Position{
+								Filename: "inlineComments.proto",
+								Offset:   57,
+								Line:     3,
+								Column:   28,
+							},
 						},
 					},
 					&parser.Package{
@@ -719,6 +927,12 @@ service SearchService {
 									Line:     4,
 									Column:   18,
 								},
+								LastPos: meta.Position{
+									Filename: "inlineComments.proto",
+									Offset:   98,
+									Line:     4,
+									Column:   30,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -728,6 +942,12 @@ service SearchService {
 								Line:     4,
 								Column:   1,
 							},
+							LastPos: meta.Position{
+								Filename: "inlineComments.proto",
+								Offset:   84,
+								Line:     4,
+								Column:   16,
+							},
 						},
 					},
 					&parser.Option{
@@ -742,6 +962,12 @@ service SearchService {
 									Line:     5,
 									Column:   42,
 								},
+								LastPos: meta.Position{
+									Filename: "inlineComments.proto",
+									Offset:   149,
+									Line:     5,
+									Column:   50,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -751,6 +977,12 @@ service SearchService {
 								Line:     5,
 								Column:   1,
 							},
+							LastPos: meta.Position{
+								Filename: "inlineComments.proto",
+								Offset:   139,
+								Line:     5,
+								Column:   40,
+							},
 						},
 					},
 					&parser.Message{
@@ -764,6 +996,12 @@ service SearchService {
 									Line:     7,
 									Column:   3,
 								},
+								LastPos: meta.Position{
+									Filename: "inlineComments.proto",
+									Offset:   178,
+									Line:     7,
+									Column:   12,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -794,6 +1032,12 @@ service SearchService {
 										Line:     9,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "inlineComments.proto",
+										Offset:   232,
+										Line:     9,
+										Column:   28,
+									},
 								},
 							},
 						},
@@ -806,6 +1050,12 @@ service SearchService {
 									Line:     10,
 									Column:   3,
 								},
+								LastPos: meta.Position{
+									Filename: "inlineComments.proto",
+									Offset:   242,
+									Line:     10,
+									Column:   9,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -837,6 +1087,12 @@ service SearchService {
 											Line:     12,
 											Column:   14,
 										},
+										LastPos: meta.Position{
+											Filename: "inlineComments.proto",
+											Offset:   295,
+											Line:     12,
+											Column:   28,
+										},
 									},
 								},
 								RPCResponse: &parser.RPCResponse{
@@ -848,6 +1104,12 @@ service SearchService {
 											Line:     12,
 											Column:   38,
 										},
+										LastPos: meta.Position{
+											Filename: "inlineComments.proto",
+											Offset:   320,
+											Line:     12,
+											Column:   53,
+										},
 									},
 								},
 								Meta: meta.Meta{
@@ -875,6 +1137,12 @@ service SearchService {
 									Line:     13,
 									Column:   3,
 								},
+								LastPos: meta.Position{
+									Filename: "inlineComments.proto",
+									Offset:   334,
+									Line:     13,
+									Column:   12,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -918,6 +1186,12 @@ service SearchService {
 							Line:     2,
 							Column:   1,
 						},
+						LastPos: meta.Position{
+							Filename: "service.proto",
+							Offset:   18,
+							Line:     2,
+							Column:   18,
+						},
 					},
 				},
 				ProtoBody: []parser.Visitee{
@@ -935,6 +1209,12 @@ service SearchService {
 											Line:     4,
 											Column:   14,
 										},
+										LastPos: meta.Position{
+											Filename: "service.proto",
+											Offset:   71,
+											Line:     4,
+											Column:   28,
+										},
 									},
 								},
 								RPCResponse: &parser.RPCResponse{
@@ -946,6 +1226,12 @@ service SearchService {
 											Line:     4,
 											Column:   38,
 										},
+										LastPos: meta.Position{
+											Filename: "service.proto",
+											Offset:   96,
+											Line:     4,
+											Column:   53,
+										},
 									},
 								},
 								Meta: meta.Meta{
@@ -1007,6 +1293,12 @@ service SearchService {
 							Line:     2,
 							Column:   1,
 						},
+						LastPos: meta.Position{
+							Filename: "service.proto",
+							Offset:   18,
+							Line:     2,
+							Column:   18,
+						},
 					},
 				},
 				ProtoBody: []parser.Visitee{
@@ -1024,6 +1316,12 @@ service SearchService {
 											Line:     4,
 											Column:   14,
 										},
+										LastPos: meta.Position{
+											Filename: "service.proto",
+											Offset:   71,
+											Line:     4,
+											Column:   28,
+										},
 									},
 								},
 								RPCResponse: &parser.RPCResponse{
@@ -1035,6 +1333,12 @@ service SearchService {
 											Line:     4,
 											Column:   38,
 										},
+										LastPos: meta.Position{
+											Filename: "service.proto",
+											Offset:   96,
+											Line:     4,
+											Column:   53,
+										},
 									},
 								},
 								Meta: meta.Meta{
@@ -1077,6 +1381,12 @@ service SearchService {
 								Line:     6,
 								Column:   1,
 							},
+							LastPos: meta.Position{
+								Filename: "service.proto",
+								Offset:   121,
+								Line:     6,
+								Column:   21,
+							},
 						},
 					},
 					&parser.Comment{
@@ -1088,6 +1398,12 @@ service SearchService {
 								Line:     7,
 								Column:   1,
 							},
+							LastPos: meta.Position{
+								Filename: "service.proto",
+								Offset:   147,
+								Line:     7,
+								Column:   25,
+							},
 						},
 					},
 				},
@@ -1113,6 +1429,11 @@ extend Foo {
 							Line:   2,
 							Column: 1,
 						},
+						LastPos: meta.Position{
+							Offset: 18,
+							Line:   2,
+							Column: 18,
+						},
 					},
 				},
 				ProtoBody: []parser.Visitee{
@@ -1129,6 +1450,11 @@ extend Foo {
 										Line:   4,
 										Column: 3,
 									},
+									LastPos: meta.Position{
+										Offset: 50,
+										Line:   4,
+										Column: 18,
+									},
 								},
 							},
 						},
@@ -1187,6 +1513,12 @@ message foo {
 							Line:     2,
 							Column:   1,
 						},
+						LastPos: meta.Position{
+							Filename: "official.proto",
+							Offset:   18,
+							Line:     2,
+							Column:   18,
+						},
 					},
 				},
 				ProtoBody: []parser.Visitee{
@@ -1200,6 +1532,12 @@ message foo {
 								Line:     3,
 								Column:   1,
 							},
+							LastPos: meta.Position{
+								Filename: "official.proto",
+								Offset:   47,
+								Line:     3,
+								Column:   28,
+							},
 						},
 					},
 					&parser.Option{
@@ -1212,6 +1550,12 @@ message foo {
 								Line:     4,
 								Column:   1,
 							},
+							LastPos: meta.Position{
+								Filename: "official.proto",
+								Offset:   88,
+								Line:     4,
+								Column:   40,
+							},
 						},
 					},
 					&parser.Enum{
@@ -1227,6 +1571,12 @@ message foo {
 										Line:     6,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "official.proto",
+										Offset:   142,
+										Line:     6,
+										Column:   28,
+									},
 								},
 							},
 							&parser.EnumField{
@@ -1239,6 +1589,12 @@ message foo {
 										Line:     7,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "official.proto",
+										Offset:   157,
+										Line:     7,
+										Column:   14,
+									},
 								},
 							},
 							&parser.EnumField{
@@ -1251,6 +1607,12 @@ message foo {
 										Line:     8,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "official.proto",
+										Offset:   172,
+										Line:     8,
+										Column:   14,
+									},
 								},
 							},
 							&parser.EnumField{
@@ -1269,6 +1631,12 @@ message foo {
 										Line:     9,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "official.proto",
+										Offset:   221,
+										Line:     9,
+										Column:   48,
+									},
 								},
 							},
 						},
@@ -1300,6 +1668,12 @@ message foo {
 										Line:     12,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "official.proto",
+										Offset:   270,
+										Line:     12,
+										Column:   30,
+									},
 								},
 							},
 							&parser.Message{
@@ -1317,6 +1691,12 @@ message foo {
 												Line:     14,
 												Column:   5,
 											},
+											LastPos: meta.Position{
+												Filename: "official.proto",
+												Offset:   330,
+												Line:     14,
+												Column:   28,
+											},
 										},
 									},
 								},
@@ -1329,6 +1709,12 @@ message foo {
 											Line:     13,
 											Column:   21,
 										},
+										LastPos: meta.Position{
+											Filename: "official.proto",
+											Offset:   301,
+											Line:     13,
+											Column:   30,
+										},
 									},
 								},
 								Meta: meta.Meta{
@@ -1358,6 +1744,12 @@ message foo {
 										Line:     16,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "official.proto",
+										Offset:   370,
+										Line:     16,
+										Column:   35,
+									},
 								},
 							},
 							&parser.Field{
@@ -1372,6 +1764,12 @@ message foo {
 										Line:     17,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "official.proto",
+										Offset:   415,
+										Line:     17,
+										Column:   44,
+									},
 								},
 							},
 							&parser.MapField{
@@ -1386,6 +1784,12 @@ message foo {
 										Line:     18,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "official.proto",
+										Offset:   448,
+										Line:     18,
+										Column:   32,
+									},
 								},
 							},
 							&parser.Extensions{
@@ -1402,6 +1806,12 @@ message foo {
 										Line:     19,
 										Column:   3,
 									},
+									LastPos: meta.Position{
+										Filename: "official.proto",
+										Offset:   471,
+										Line:     19,
+										Column:   22,
+									},
 								},
 							},
 						},
@@ -1440,6 +1850,12 @@ message foo {
 												Line:     23,
 												Column:   5,
 											},
+											LastPos: meta.Position{
+												Filename: "official.proto",
+												Offset:   549,
+												Line:     23,
+												Column:   25,
+											},
 										},
 									},
 								},
@@ -1480,17 +1896,239 @@ message foo {
 				},
 			},
 		},
-	}
-
-	for _, test := range tests {
-		test := test
-		t.Run(test.name, func(t *testing.T) {
-			p := parser.NewParser(
-				lexer.NewLexer(
-					strings.NewReader(test.input),
-					lexer.WithFilename(test.filename),
+		{
+			name: "parsing empty statements followed by an inline comment",
+			input: `syntax = "proto2";;// comment
+`,
+			wantProto: &parser.Proto{
+				Syntax: &parser.Syntax{
+					ProtobufVersion: "proto2",
+					Meta: meta.Meta{
+						Pos: meta.Position{
+							Offset: 0,
+							Line:   1,
+							Column: 1,
+						},
+						LastPos: meta.Position{
+							Offset: 17,
+							Line:   1,
+							Column: 18,
+						},
+					},
+				},
+				ProtoBody: []parser.Visitee{
+					&parser.EmptyStatement{
+						InlineComment: &parser.Comment{
+							Raw: "// comment",
+							Meta: meta.Meta{
+								Pos: meta.Position{
+									Offset: 19,
+									Line:   1,
+									Column: 20,
+								},
+								LastPos: meta.Position{
+									Offset: 28,
+									Line:   1,
+									Column: 29,
+								},
+							},
+						},
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 18,
+								Line:   1,
+								Column: 19,
+							},
+							LastPos: meta.Position{
+								Offset: 18,
+								Line:   1,
+								Column: 19,
+							},
+						},
+					},
+				},
+				Meta: &parser.ProtoMeta{},
+			},
+		},
+		{
+			name: "not parsing commented-out declarations",
+			input: `
+syntax = "proto3";
+// message Foo {}
+/* service S { rpc R (A) returns (B); } */
+// option go_package = "x";
+`,
+			inputBodyIncludingComments: true,
+			wantProto: &parser.Proto{
+				Syntax: &parser.Syntax{
+					ProtobufVersion: "proto3",
+					Meta: meta.Meta{
+						Pos: meta.Position{
+							Offset: 1,
+							Line:   2,
+							Column: 1,
+						},
+						LastPos: meta.Position{
+							Offset: 18,
+							Line:   2,
+							Column: 18,
+						},
+					},
+				},
+				ProtoBody: []parser.Visitee{
+					&parser.Comment{
+						Raw: `// message Foo {}`,
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 20,
+								Line:   3,
+								Column: 1,
+							},
+							LastPos: meta.Position{
+								Offset: 36,
+								Line:   3,
+								Column: 17,
+							},
+						},
+					},
+					&parser.Comment{
+						Raw: `/* service S { rpc R (A) returns (B); } */`,
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 38,
+								Line:   4,
+								Column: 1,
+							},
+							LastPos: meta.Position{
+								Offset: 79,
+								Line:   4,
+								Column: 42,
+							},
+						},
+					},
+					&parser.Comment{
+						Raw: `// option go_package = "x";`,
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 81,
+								Line:   5,
+								Column: 1,
+							},
+							LastPos: meta.Position{
+								Offset: 107,
+								Line:   5,
+								Column: 27,
+							},
+						},
+					},
+				},
+				Meta: &parser.ProtoMeta{},
+			},
+		},
+		{
+			name: "parsing a trailing comment after the closing brace by permissive mode",
+			input: `syntax = "proto3";
+message M {
+} // end of M
+// Comment of N.
+message N {}
+`,
+			permissive: true,
+			wantProto: &parser.Proto{
+				Syntax: &parser.Syntax{
+					ProtobufVersion: "proto3",
+					Meta: meta.Meta{
+						Pos: meta.Position{
+							Offset: 0,
+							Line:   1,
+							Column: 1,
+						},
+						LastPos: meta.Position{
+							Offset: 17,
+							Line:   1,
+							Column: 18,
+						},
+					},
+				},
+				ProtoBody: []parser.Visitee{
+					&parser.Message{
+						MessageName: "M",
+						InlineComment: &parser.Comment{
+							Raw: "// end of M",
+							Meta: meta.Meta{
+								Pos: meta.Position{
+									Offset: 33,
+									Line:   3,
+									Column: 3,
+								},
+								LastPos: meta.Position{
+									Offset: 43,
+									Line:   3,
+									Column: 13,
+								},
+							},
+						},
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 19,
+								Line:   2,
+								Column: 1,
+							},
+							LastPos: meta.Position{
+								Offset: 31,
+								Line:   3,
+								Column: 1,
+							},
+						},
+					},
+					&parser.Message{
+						MessageName: "N",
+						Comments: []*parser.Comment{
+							{
+								Raw: "// Comment of N.",
+								Meta: meta.Meta{
+									Pos: meta.Position{
+										Offset: 45,
+										Line:   4,
+										Column: 1,
+									},
+									LastPos: meta.Position{
+										Offset: 60,
+										Line:   4,
+										Column: 16,
+									},
+								},
+							},
+						},
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 62,
+								Line:   5,
+								Column: 1,
+							},
+							LastPos: meta.Position{
+								Offset: 73,
+								Line:   5,
+								Column: 12,
+							},
+						},
+					},
+				},
+				Meta: &parser.ProtoMeta{},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(
+				lexer.NewLexer(
+					strings.NewReader(test.input),
+					lexer.WithFilename(test.filename),
 				),
 				parser.WithBodyIncludingComments(test.inputBodyIncludingComments),
+				parser.WithPermissive(test.permissive),
 			)
 			got, err := p.ParseProto()
 			switch {
@@ -1514,3 +2152,461 @@ message foo {
 		})
 	}
 }
+
+func TestProto_PackageName(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		wantPackageName string
+		wantBodyLen     int
+	}{
+		{
+			name: "parsing a proto with a package",
+			input: `
+syntax = "proto3";
+package foo.bar;
+import "other.proto";
+message Outer {}
+`,
+			wantPackageName: "foo.bar",
+			wantBodyLen:     3,
+		},
+		{
+			name: "parsing a proto without a package",
+			input: `
+syntax = "proto3";
+import "other.proto";
+import public "another.proto";
+message Outer {
+  message Inner {}
+  Inner inner = 1;
+}
+`,
+			wantBodyLen: 3,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			got, err := p.ParseProto()
+			if err != nil {
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			if got.PackageName() != test.wantPackageName {
+				t.Errorf("got %q, but want %q", got.PackageName(), test.wantPackageName)
+			}
+			if len(got.ProtoBody) != test.wantBodyLen {
+				t.Errorf("got %d, but want %d", len(got.ProtoBody), test.wantBodyLen)
+			}
+		})
+	}
+}
+
+func TestProto_Imports(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		wantLocations []string
+		wantWellKnown []bool
+	}{
+		{
+			name: "parsing well-known and local imports",
+			input: `
+syntax = "proto3";
+import "google/protobuf/timestamp.proto";
+package foo;
+import public 'my/local.proto';
+import weak "google/api/annotations.proto";
+message Outer {}
+`,
+			wantLocations: []string{
+				`"google/protobuf/timestamp.proto"`,
+				`'my/local.proto'`,
+				`"google/api/annotations.proto"`,
+			},
+			wantWellKnown: []bool{true, false, false},
+		},
+		{
+			name: "parsing no imports",
+			input: `
+syntax = "proto3";
+message Outer {}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			got, err := p.ParseProto()
+			if err != nil {
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			var gotLocations []string
+			var gotWellKnown []bool
+			for _, i := range got.Imports() {
+				gotLocations = append(gotLocations, i.Location)
+				gotWellKnown = append(gotWellKnown, i.IsWellKnown())
+			}
+			if !reflect.DeepEqual(gotLocations, test.wantLocations) {
+				t.Errorf("got %v, but want %v", gotLocations, test.wantLocations)
+			}
+			if !reflect.DeepEqual(gotWellKnown, test.wantWellKnown) {
+				t.Errorf("got %v, but want %v", gotWellKnown, test.wantWellKnown)
+			}
+		})
+	}
+}
+
+func TestProto_BoolFileOption(t *testing.T) {
+	input := `
+syntax = "proto3";
+option java_package = "com.example.foo";
+option cc_enable_arenas = true;
+option java_multiple_files = false;
+option (my_option) = True;
+message Outer {
+  option deprecated = true;
+}
+`
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+	proto, err := p.ParseProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		inputName string
+		wantValue bool
+		wantSet   bool
+		wantFound bool
+	}{
+		{
+			name:      "fetching a present true option",
+			inputName: "cc_enable_arenas",
+			wantValue: true,
+			wantSet:   true,
+			wantFound: true,
+		},
+		{
+			name:      "fetching a present false option",
+			inputName: "java_multiple_files",
+			wantSet:   true,
+			wantFound: true,
+		},
+		{
+			name:      "fetching a present non-boolean option",
+			inputName: "java_package",
+			wantFound: true,
+		},
+		{
+			name:      "fetching a present non-canonical boolean option",
+			inputName: "(my_option)",
+			wantFound: true,
+		},
+		{
+			name:      "fetching an absent option declared only in a message",
+			inputName: "deprecated",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, found := proto.FileOption(test.inputName)
+			if found != test.wantFound {
+				t.Errorf("got %v, but want %v", found, test.wantFound)
+			}
+
+			value, set := proto.BoolFileOption(test.inputName)
+			if value != test.wantValue || set != test.wantSet {
+				t.Errorf("got (%v, %v), but want (%v, %v)", value, set, test.wantValue, test.wantSet)
+			}
+		})
+	}
+}
+
+func TestParser_ParseProto_declarationOrder(t *testing.T) {
+	input := `syntax = "proto2";
+option java_package = "com.example.foo";
+import "other.proto";
+message Outer {}
+package foo;
+enum Corpus {
+  UNIVERSAL = 0;
+}
+import public "another.proto";
+service SearchService {}
+extend Outer {
+  optional int32 bar = 126;
+}
+option optimize_for = SPEED;
+`
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+	proto, err := p.ParseProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, body := range proto.ProtoBody {
+		got = append(got, fmt.Sprintf("%T", body))
+	}
+	want := []string{
+		"*parser.Option",
+		"*parser.Import",
+		"*parser.Message",
+		"*parser.Package",
+		"*parser.Enum",
+		"*parser.Import",
+		"*parser.Service",
+		"*parser.Extend",
+		"*parser.Option",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, but want %v", got, want)
+	}
+}
+
+func TestParser_ParseProto_syntaxAndEdition(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name: "parsing an edition after a syntax",
+			input: `syntax = "proto3";
+edition = "2023";
+`,
+			wantErr: `expected [a single syntax or edition declaration at the beginning of the file], found "edition" at <input>:2:1`,
+		},
+		{
+			name: "parsing a syntax after an edition and a comment",
+			input: `edition = "2023";
+package foo;
+// a comment
+syntax = "proto2";
+`,
+			wantErr: `expected [a single syntax or edition declaration at the beginning of the file], found "syntax" at <input>:4:1`,
+		},
+		{
+			name: "parsing a second syntax",
+			input: `syntax = "proto3";
+syntax = "proto3";
+`,
+			wantErr: `expected [a single syntax or edition declaration at the beginning of the file], found "syntax" at <input>:2:1`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			_, err := p.ParseProto()
+			if err == nil {
+				t.Fatalf("got err nil, but want %q", test.wantErr)
+			}
+			if err.Error() != test.wantErr {
+				t.Errorf("got err %q, but want %q", err.Error(), test.wantErr)
+			}
+		})
+	}
+}
+
+// cancelingReader cancels the context once the first chunk is read.
+type cancelingReader struct {
+	r      io.Reader
+	cancel context.CancelFunc
+}
+
+func (r *cancelingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.cancel()
+	return n, err
+}
+
+func TestParser_ParseProtoContext(t *testing.T) {
+	var b strings.Builder
+	b.WriteString(`syntax = "proto3";` + "\n")
+	for i := 0; i < 2000; i++ {
+		fmt.Fprintf(&b, "message M%d {\n  int32 a = 1;\n}\n", i)
+	}
+	input := b.String()
+
+	t.Run("parsing with a live context", func(t *testing.T) {
+		p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+		got, err := p.ParseProtoContext(context.Background())
+		if err != nil {
+			t.Fatalf("got err %v, but want nil", err)
+		}
+		if len(got.ProtoBody) != 2000 {
+			t.Errorf("got %d, but want 2000", len(got.ProtoBody))
+		}
+	})
+
+	t.Run("parsing with a context canceled beforehand", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+		_, err := p.ParseProtoContext(ctx)
+		if err != context.Canceled {
+			t.Errorf("got err %v, but want %v", err, context.Canceled)
+		}
+	})
+
+	t.Run("parsing with a context canceled while parsing", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		p := parser.NewParser(lexer.NewLexer(&cancelingReader{r: strings.NewReader(input), cancel: cancel}))
+		_, err := p.ParseProtoContext(ctx)
+		if err != context.Canceled {
+			t.Errorf("got err %v, but want %v", err, context.Canceled)
+		}
+	})
+}
+
+func TestParser_ParseProto_emptyStatements(t *testing.T) {
+	input := `syntax = "proto3";
+;
+package foo;;
+message Foo {
+  ;
+  int32 a = 1;;
+  message Bar {;}
+}
+;
+`
+	type element struct {
+		typ  string
+		line int
+	}
+	describe := func(body []parser.Visitee) []element {
+		var elements []element
+		for _, v := range body {
+			switch e := v.(type) {
+			case *parser.EmptyStatement:
+				elements = append(elements, element{typ: "EmptyStatement", line: e.Meta.Pos.Line})
+			case *parser.Package:
+				elements = append(elements, element{typ: "Package", line: e.Meta.Pos.Line})
+			case *parser.Message:
+				elements = append(elements, element{typ: "Message", line: e.Meta.Pos.Line})
+			case *parser.Field:
+				elements = append(elements, element{typ: "Field", line: e.Meta.Pos.Line})
+			default:
+				elements = append(elements, element{typ: fmt.Sprintf("%T", v)})
+			}
+		}
+		return elements
+	}
+
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+	got, err := p.ParseProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantProtoBody := []element{
+		{typ: "EmptyStatement", line: 2},
+		{typ: "Package", line: 3},
+		{typ: "EmptyStatement", line: 3},
+		{typ: "Message", line: 4},
+		{typ: "EmptyStatement", line: 9},
+	}
+	if gotProtoBody := describe(got.ProtoBody); !reflect.DeepEqual(gotProtoBody, wantProtoBody) {
+		t.Errorf("got %v, but want %v", gotProtoBody, wantProtoBody)
+	}
+
+	foo := got.ProtoBody[3].(*parser.Message)
+	wantMessageBody := []element{
+		{typ: "EmptyStatement", line: 5},
+		{typ: "Field", line: 6},
+		{typ: "EmptyStatement", line: 6},
+		{typ: "Message", line: 7},
+	}
+	if gotMessageBody := describe(foo.MessageBody); !reflect.DeepEqual(gotMessageBody, wantMessageBody) {
+		t.Errorf("got %v, but want %v", gotMessageBody, wantMessageBody)
+	}
+
+	bar := foo.MessageBody[3].(*parser.Message)
+	wantNestedBody := []element{
+		{typ: "EmptyStatement", line: 7},
+	}
+	if gotNestedBody := describe(bar.MessageBody); !reflect.DeepEqual(gotNestedBody, wantNestedBody) {
+		t.Errorf("got %v, but want %v", gotNestedBody, wantNestedBody)
+	}
+}
+
+func TestParser_ParseProto_crlf(t *testing.T) {
+	input := "syntax = \"proto3\";\r\n" +
+		"// c\r\n" +
+		"message A {\n" +
+		"  int32 a = 1; // d\r\n" +
+		"}\r\n"
+
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+	got, err := p.ParseProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := got.ProtoBody[0].(*parser.Message)
+	field := message.MessageBody[0].(*parser.Field)
+
+	tests := []struct {
+		name    string
+		gotPos  meta.Position
+		wantPos meta.Position
+	}{
+		{
+			name:    "the comment after CRLF",
+			gotPos:  message.Comments[0].Meta.Pos,
+			wantPos: meta.Position{Offset: 20, Line: 2, Column: 1},
+		},
+		{
+			name:    "the end of the comment before CRLF",
+			gotPos:  message.Comments[0].Meta.LastPos,
+			wantPos: meta.Position{Offset: 23, Line: 2, Column: 4},
+		},
+		{
+			name:    "the message after CRLF",
+			gotPos:  message.Meta.Pos,
+			wantPos: meta.Position{Offset: 26, Line: 3, Column: 1},
+		},
+		{
+			name:    "the field after LF",
+			gotPos:  field.Meta.Pos,
+			wantPos: meta.Position{Offset: 40, Line: 4, Column: 3},
+		},
+		{
+			name:    "the inline comment before CRLF",
+			gotPos:  field.InlineComment.Meta.LastPos,
+			wantPos: meta.Position{Offset: 56, Line: 4, Column: 19},
+		},
+		{
+			name:    "the closing brace after CRLF",
+			gotPos:  message.Meta.LastPos,
+			wantPos: meta.Position{Offset: 59, Line: 5, Column: 1},
+		},
+	}
+	for _, test := range tests {
+		if test.gotPos != test.wantPos {
+			t.Errorf("%s: got %+v, but want %+v", test.name, test.gotPos, test.wantPos)
+		}
+	}
+
+	for _, comment := range []*parser.Comment{message.Comments[0], field.InlineComment} {
+		if strings.HasSuffix(comment.Raw, "\r") {
+			t.Errorf("got %q, but want the comment without CR", comment.Raw)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package parser
+
+// QualifiedNames returns the fully-qualified names of the messages and enums defined in the proto,
+// including the nested ones at any depth, e.g. "pkg.Outer.Inner.Color".
+// A group field also defines a message type named after the group.
+// The names are listed in the depth-first declaration order and don't have the leading dot.
+func (p *Proto) QualifiedNames() []string {
+	var names []string
+	for _, body := range p.ProtoBody {
+		names = appendQualifiedNames(names, p.PackageName(), body)
+	}
+	return names
+}
+
+func appendQualifiedNames(names []string, scope string, element Visitee) []string {
+	switch e := element.(type) {
+	case *Message:
+		name := qualifyName(scope, e.MessageName)
+		names = append(names, name)
+		for _, body := range e.MessageBody {
+			names = appendQualifiedNames(names, name, body)
+		}
+	case *GroupField:
+		name := qualifyName(scope, e.GroupName)
+		names = append(names, name)
+		for _, body := range e.MessageBody {
+			names = appendQualifiedNames(names, name, body)
+		}
+	case *Enum:
+		names = append(names, qualifyName(scope, e.EnumName))
+	}
+	return names
+}
+
+func qualifyName(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// QualifiedName returns the fully-qualified name of the node declared in the proto without the leading dot,
+// like "pkg.Outer.Inner" for a nested message and "pkg.Outer.Inner.field" for its field.
+// The node is one of *Message, *GroupField, *Enum, *EnumField, *Service, *RPC, *Field, *MapField, *Oneof and *OneofField.
+// A group field is named after the message type it defines.
+// As protobuf scopes them, an enum value belongs to the scope enclosing the enum, a oneof field to the message,
+// and a field in an extend to the scope enclosing the extend.
+// It returns false when the node isn't declared in the proto.
+func (p *Proto) QualifiedName(node interface{}) (string, bool) {
+	for _, body := range p.ProtoBody {
+		if name, ok := qualifiedNameIn(p.PackageName(), body, node); ok {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// qualifiedNameIn searches the element declared in the scope and its children for the node.
+func qualifiedNameIn(scope string, element Visitee, node interface{}) (string, bool) {
+	searchBody := func(scope string, body []Visitee) (string, bool) {
+		for _, child := range body {
+			if name, ok := qualifiedNameIn(scope, child, node); ok {
+				return name, true
+			}
+		}
+		return "", false
+	}
+
+	switch e := element.(type) {
+	case *Message:
+		name := qualifyName(scope, e.MessageName)
+		if e == node {
+			return name, true
+		}
+		return searchBody(name, e.MessageBody)
+	case *GroupField:
+		name := qualifyName(scope, e.GroupName)
+		if e == node {
+			return name, true
+		}
+		return searchBody(name, e.MessageBody)
+	case *Enum:
+		if e == node {
+			return qualifyName(scope, e.EnumName), true
+		}
+		return searchBody(scope, e.EnumBody)
+	case *EnumField:
+		if e == node {
+			return qualifyName(scope, e.Ident), true
+		}
+	case *Service:
+		name := qualifyName(scope, e.ServiceName)
+		if e == node {
+			return name, true
+		}
+		return searchBody(name, e.ServiceBody)
+	case *RPC:
+		if e == node {
+			return qualifyName(scope, e.RPCName), true
+		}
+	case *Extend:
+		return searchBody(scope, e.ExtendBody)
+	case *Field:
+		if e == node {
+			return qualifyName(scope, e.FieldName), true
+		}
+	case *MapField:
+		if e == node {
+			return qualifyName(scope, e.MapName), true
+		}
+	case *Oneof:
+		if e == node {
+			return qualifyName(scope, e.OneofName), true
+		}
+		for _, field := range e.OneofFields {
+			if field == node {
+				return qualifyName(scope, field.FieldName), true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,218 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestProto_QualifiedNames(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantNames []string
+	}{
+		{
+			name: "parsing enums nested two levels deep",
+			input: `
+syntax = "proto3";
+package pkg;
+message Outer {
+  message Inner {
+    enum Color {
+      RED = 0;
+    }
+    Color color = 1;
+  }
+  enum Kind {
+    UNKNOWN = 0;
+  }
+  Inner inner = 1;
+}
+enum Top {
+  TOP = 0;
+}
+`,
+			wantNames: []string{
+				"pkg.Outer",
+				"pkg.Outer.Inner",
+				"pkg.Outer.Inner.Color",
+				"pkg.Outer.Kind",
+				"pkg.Top",
+			},
+		},
+		{
+			name: "parsing a group and no package",
+			input: `
+syntax = "proto2";
+message SearchResponse {
+  repeated group Result = 1 {
+    required string url = 2;
+    enum Status {
+      OK = 0;
+    }
+  }
+}
+`,
+			wantNames: []string{
+				"SearchResponse",
+				"SearchResponse.Result",
+				"SearchResponse.Result.Status",
+			},
+		},
+		{
+			name: "parsing no types",
+			input: `
+syntax = "proto3";
+package pkg;
+service SearchService {
+  rpc Search (SearchRequest) returns (SearchResponse);
+}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			got, err := p.ParseProto()
+			if err != nil {
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			gotNames := got.QualifiedNames()
+			if !reflect.DeepEqual(gotNames, test.wantNames) {
+				t.Errorf("got %v, but want %v", gotNames, test.wantNames)
+			}
+		})
+	}
+}
+
+func TestProto_QualifiedName(t *testing.T) {
+	input := `
+syntax = "proto2";
+package pkg;
+message Outer {
+  message Inner {
+    enum Color {
+      RED = 0;
+    }
+    optional Color color = 1;
+  }
+  oneof choice {
+    string name = 2;
+  }
+  map<string, Inner> inners = 3;
+  optional group Result = 4 {
+    optional string url = 5;
+  }
+  extensions 100 to 199;
+}
+extend Outer {
+  optional int32 extra = 100;
+}
+service S {
+  rpc Get(Outer) returns (Outer);
+}
+`
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+	proto, err := p.ParseProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outer := proto.ProtoBody[1].(*parser.Message)
+	inner := outer.MessageBody[0].(*parser.Message)
+	color := inner.MessageBody[0].(*parser.Enum)
+	oneof := outer.MessageBody[1].(*parser.Oneof)
+	group := outer.MessageBody[3].(*parser.GroupField)
+	extend := proto.ProtoBody[2].(*parser.Extend)
+	service := proto.ProtoBody[3].(*parser.Service)
+
+	tests := []struct {
+		name     string
+		node     interface{}
+		wantName string
+		wantOK   bool
+	}{
+		{
+			name:     "a top-level message",
+			node:     outer,
+			wantName: "pkg.Outer",
+			wantOK:   true,
+		},
+		{
+			name:     "a nested enum",
+			node:     color,
+			wantName: "pkg.Outer.Inner.Color",
+			wantOK:   true,
+		},
+		{
+			name:     "an enum value scoped in the enclosing message",
+			node:     color.EnumBody[0],
+			wantName: "pkg.Outer.Inner.RED",
+			wantOK:   true,
+		},
+		{
+			name:     "a field of a nested message",
+			node:     inner.MessageBody[1],
+			wantName: "pkg.Outer.Inner.color",
+			wantOK:   true,
+		},
+		{
+			name:     "a oneof",
+			node:     oneof,
+			wantName: "pkg.Outer.choice",
+			wantOK:   true,
+		},
+		{
+			name:     "a oneof field scoped in the message",
+			node:     oneof.OneofFields[0],
+			wantName: "pkg.Outer.name",
+			wantOK:   true,
+		},
+		{
+			name:     "a map field",
+			node:     outer.MessageBody[2],
+			wantName: "pkg.Outer.inners",
+			wantOK:   true,
+		},
+		{
+			name:     "a field in a group",
+			node:     group.MessageBody[0],
+			wantName: "pkg.Outer.Result.url",
+			wantOK:   true,
+		},
+		{
+			name:     "a field in an extend",
+			node:     extend.ExtendBody[0],
+			wantName: "pkg.extra",
+			wantOK:   true,
+		},
+		{
+			name:     "an rpc",
+			node:     service.ServiceBody[0],
+			wantName: "pkg.S.Get",
+			wantOK:   true,
+		},
+		{
+			name: "a node which isn't declared in the proto",
+			node: &parser.Message{MessageName: "Outer"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := proto.QualifiedName(test.node)
+			if got != test.wantName || ok != test.wantOK {
+				t.Errorf("got (%q, %v), but want (%q, %v)", got, ok, test.wantName, test.wantOK)
+			}
+		})
+	}
+}
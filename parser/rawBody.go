@@ -0,0 +1,122 @@
+package parser
+
+import "github.com/yoheimuta/go-protoparser/v4/parser/meta"
+
+// rawBodySetter sets the source text of each element to its Meta.Raw.
+// The comments are skipped, which keep their text in Comment.Raw already.
+type rawBodySetter struct {
+	source string
+}
+
+func (r *rawBodySetter) set(m *meta.Meta) {
+	start, end := m.ByteRange()
+	if start < end && end <= len(r.source) {
+		m.Raw = r.source[start:end]
+	}
+}
+
+func (r *rawBodySetter) VisitComment(*Comment) {}
+
+func (r *rawBodySetter) VisitEdition(e *Edition) bool {
+	r.set(&e.Meta)
+	return false
+}
+
+func (r *rawBodySetter) VisitEmptyStatement(e *EmptyStatement) bool {
+	r.set(&e.Meta)
+	return false
+}
+
+func (r *rawBodySetter) VisitEnum(e *Enum) bool {
+	r.set(&e.Meta)
+	return true
+}
+
+func (r *rawBodySetter) VisitEnumField(e *EnumField) bool {
+	r.set(&e.Meta)
+	return false
+}
+
+func (r *rawBodySetter) VisitExtend(e *Extend) bool {
+	r.set(&e.Meta)
+	return true
+}
+
+func (r *rawBodySetter) VisitExtensions(e *Extensions) bool {
+	r.set(&e.Meta)
+	return false
+}
+
+func (r *rawBodySetter) VisitField(f *Field) bool {
+	r.set(&f.Meta)
+	return false
+}
+
+func (r *rawBodySetter) VisitGroupField(g *GroupField) bool {
+	r.set(&g.Meta)
+	return true
+}
+
+func (r *rawBodySetter) VisitImport(i *Import) bool {
+	r.set(&i.Meta)
+	return false
+}
+
+func (r *rawBodySetter) VisitMapField(m *MapField) bool {
+	r.set(&m.Meta)
+	return false
+}
+
+func (r *rawBodySetter) VisitMessage(m *Message) bool {
+	r.set(&m.Meta)
+	return true
+}
+
+func (r *rawBodySetter) VisitOneof(o *Oneof) bool {
+	r.set(&o.Meta)
+	return true
+}
+
+func (r *rawBodySetter) VisitOneofField(f *OneofField) bool {
+	r.set(&f.Meta)
+	return false
+}
+
+func (r *rawBodySetter) VisitOption(o *Option) bool {
+	r.set(&o.Meta)
+	return false
+}
+
+func (r *rawBodySetter) VisitPackage(p *Package) bool {
+	r.set(&p.Meta)
+	return false
+}
+
+func (r *rawBodySetter) VisitReserved(rs *Reserved) bool {
+	r.set(&rs.Meta)
+	return false
+}
+
+func (r *rawBodySetter) VisitRPC(rpc *RPC) bool {
+	r.set(&rpc.Meta)
+	if rpc.RPCRequest != nil {
+		r.set(&rpc.RPCRequest.Meta)
+	}
+	if rpc.RPCResponse != nil {
+		r.set(&rpc.RPCResponse.Meta)
+	}
+	for _, option := range rpc.Options {
+		option.Accept(r)
+	}
+	return false
+}
+
+func (r *rawBodySetter) VisitService(s *Service) bool {
+	r.set(&s.Meta)
+	return true
+}
+
+func (r *rawBodySetter) VisitSyntax(s *Syntax) bool {
+	r.set(&s.Meta)
+	return false
+}
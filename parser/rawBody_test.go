@@ -0,0 +1,85 @@
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestWithRawBody(t *testing.T) {
+	input := "syntax = \"proto3\";\r\n" +
+		"// Foo is a message.\n" +
+		"message Foo {\n" +
+		"  // a\n" +
+		"  string   a = 1 [ deprecated=true ]; // a\n" +
+		"  oneof o { int32 b = 2; }\n" +
+		"}\n" +
+		"service S {\n" +
+		"  rpc R (stream Foo) returns (Foo) { option deprecated = true; }\n" +
+		"}\n"
+
+	tests := []struct {
+		name    string
+		rawBody bool
+		want    []string
+	}{
+		{
+			name:    "keeping the raw texts",
+			rawBody: true,
+			want: []string{
+				`syntax = "proto3";`,
+				"message Foo {\n  // a\n  string   a = 1 [ deprecated=true ]; // a\n  oneof o { int32 b = 2; }\n}",
+				`string   a = 1 [ deprecated=true ];`,
+				`oneof o { int32 b = 2; }`,
+				`int32 b = 2;`,
+				"service S {\n  rpc R (stream Foo) returns (Foo) { option deprecated = true; }\n}",
+				`rpc R (stream Foo) returns (Foo) { option deprecated = true; }`,
+				`(stream Foo)`,
+				`(Foo)`,
+				`option deprecated = true;`,
+			},
+		},
+		{
+			name: "keeping no raw texts by default",
+			want: make([]string, 10),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(
+				lexer.NewLexer(strings.NewReader(input)),
+				parser.WithRawBody(test.rawBody),
+			)
+			proto, err := p.ParseProto()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			message := proto.ProtoBody[0].(*parser.Message)
+			oneof := message.MessageBody[1].(*parser.Oneof)
+			service := proto.ProtoBody[1].(*parser.Service)
+			rpc := service.ServiceBody[0].(*parser.RPC)
+			got := []string{
+				proto.Syntax.Meta.Raw,
+				message.Meta.Raw,
+				message.MessageBody[0].(*parser.Field).Meta.Raw,
+				oneof.Meta.Raw,
+				oneof.OneofFields[0].Meta.Raw,
+				service.Meta.Raw,
+				rpc.Meta.Raw,
+				rpc.RPCRequest.Meta.Raw,
+				rpc.RPCResponse.Meta.Raw,
+				rpc.Options[0].Meta.Raw,
+			}
+			for i := range test.want {
+				if got[i] != test.want[i] {
+					t.Errorf("got %q, but want %q", got[i], test.want[i])
+				}
+			}
+		})
+	}
+}
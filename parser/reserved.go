@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/yoheimuta/go-protoparser/v4/internal/lexer/scanner"
 	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
@@ -16,18 +17,56 @@ func (e *parseReservedErr) Error() string {
 	return fmt.Sprintf("%v:%v", e.parseRangesErr, e.parseFieldNamesErr)
 }
 
+// Unwrap returns the error of parsing the statement as ranges.
+func (e *parseReservedErr) Unwrap() error {
+	return e.parseRangesErr
+}
+
 // Range is a range of field numbers. End is an optional value.
 type Range struct {
 	Begin string
 	End   string
 }
 
+// IsMax reports whether the range is in the form of "to max".
+func (r *Range) IsMax() bool {
+	return r.End == "max"
+}
+
+// Bounds returns the inclusive numeric bounds of the range.
+// A single number has the same begin and end, and "max" is the maximum field number 536870911.
+func (r *Range) Bounds() (begin int, end int, err error) {
+	b, err := strconv.ParseInt(r.Begin, 0, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	switch {
+	case r.End == "":
+		return int(b), int(b), nil
+	case r.IsMax():
+		return int(b), maxFieldNumber, nil
+	}
+
+	e, err := strconv.ParseInt(r.End, 0, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(b), int(e), nil
+}
+
 // Reserved declares a range of field numbers or field names that cannot be used in this message.
 // These component Ranges and FieldNames are mutually exclusive.
+// FieldNames keep their original spelling, so a quoted name includes the quotes
+// while an unquoted one, which is allowed only in the editions, doesn't.
 type Reserved struct {
 	Ranges     []*Range
 	FieldNames []string
 
+	// Multiline reports whether the statement spans multiple lines, as in
+	// a long list of ranges wrapped by the author. Each statement is kept as a
+	// separate node, so the grouping across statements is preserved as well.
+	Multiline bool
+
 	// Comments are the optional ones placed at the beginning.
 	Comments []*Comment
 	// InlineComment is the optional one placed at the ending.
@@ -36,6 +75,15 @@ type Reserved struct {
 	Meta meta.Meta
 }
 
+// UnquotedFieldNames returns the FieldNames without the surrounding quotes, either '"' or '\''.
+func (r *Reserved) UnquotedFieldNames() []string {
+	var fieldNames []string
+	for _, fieldName := range r.FieldNames {
+		fieldNames = append(fieldNames, unquote(fieldName))
+	}
+	return fieldNames
+}
+
 // SetInlineComment implements the HasInlineCommentSetter interface.
 func (r *Reserved) SetInlineComment(comment *Comment) {
 	r.InlineComment = comment
@@ -67,19 +115,32 @@ func (p *Parser) ParseReserved() (*Reserved, error) {
 	startPos := p.lex.Pos
 
 	parse := func() ([]*Range, []string, error) {
-		ranges, err := p.parseRanges()
-		if err == nil {
-			return ranges, nil, nil
+		// The first token decides whether the statement consists of ranges or fieldNames,
+		// so that the one mixing both is reported at the first token of the other kind.
+		p.lex.NextNumberLit()
+		if p.lex.Token == scanner.TINTLIT {
+			p.lex.UnNext()
+			ranges, err := p.parseRanges()
+			return ranges, nil, err
 		}
+		rangesErr := p.unexpected("intLit")
+		p.lex.UnNext()
 
-		fieldNames, ferr := p.parseFieldNames()
-		if ferr == nil {
+		p.lex.NextStrLit()
+		isFieldName := p.lex.Token == scanner.TSTRLIT || (p.edition != nil && p.lex.Token == scanner.TIDENT)
+		p.lex.UnNext()
+
+		fieldNames, err := p.parseFieldNames()
+		if err == nil {
 			return nil, fieldNames, nil
 		}
+		if isFieldName {
+			return nil, nil, err
+		}
 
 		return nil, nil, &parseReservedErr{
-			parseRangesErr:     err,
-			parseFieldNamesErr: ferr,
+			parseRangesErr:     rangesErr,
+			parseFieldNamesErr: err,
 		}
 	}
 
@@ -96,7 +157,11 @@ func (p *Parser) ParseReserved() (*Reserved, error) {
 	return &Reserved{
 		Ranges:     ranges,
 		FieldNames: fieldNames,
-		Meta:       meta.Meta{Pos: startPos.Position},
+		Multiline:  p.lex.Pos.Line != startPos.Line,
+		Meta: meta.Meta{
+			Pos:     startPos.Position,
+			LastPos: p.lex.Pos.Position,
+		},
 	}, nil
 }
 
@@ -117,6 +182,12 @@ func (p *Parser) parseRanges() ([]*Range, error) {
 			break
 		}
 
+		p.lex.NextStrLit()
+		if p.lex.Token == scanner.TSTRLIT {
+			return nil, p.unexpected("range, not a fieldName mixed with the ranges")
+		}
+		p.lex.UnNext()
+
 		rangeValue, err := p.parseRange()
 		if err != nil {
 			return nil, err
@@ -163,7 +234,7 @@ func (p *Parser) parseRange() (*Range, error) {
 func (p *Parser) parseFieldNames() ([]string, error) {
 	var fieldNames []string
 
-	fieldName, err := p.parseQuotedFieldName()
+	fieldName, err := p.parseReservedFieldName()
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +247,13 @@ func (p *Parser) parseFieldNames() ([]string, error) {
 			break
 		}
 
-		fieldName, err = p.parseQuotedFieldName()
+		p.lex.NextNumberLit()
+		if p.lex.Token == scanner.TINTLIT {
+			return nil, p.unexpected("fieldName, not a range mixed with the fieldNames")
+		}
+		p.lex.UnNext()
+
+		fieldName, err = p.parseReservedFieldName()
 		if err != nil {
 			return nil, err
 		}
@@ -185,6 +262,30 @@ func (p *Parser) parseFieldNames() ([]string, error) {
 	return fieldNames, nil
 }
 
+// reservedFieldName = quotedFieldName | ident
+// The unquoted ident is accepted only in the editions.
+// The quoted name must be an ident as well.
+// See https://protobuf.dev/reference/protobuf/edition-2023-spec/#reserved
+func (p *Parser) parseReservedFieldName() (string, error) {
+	fieldName, err := p.parseQuotedFieldName()
+	if err == nil {
+		if !scanner.IsIdent(unquote(fieldName)) {
+			return "", p.unexpected("quotedFieldName of an ident")
+		}
+		return fieldName, nil
+	}
+	if p.edition == nil {
+		return "", err
+	}
+
+	p.lex.Next()
+	if p.lex.Token != scanner.TIDENT {
+		p.lex.UnNext()
+		return "", p.unexpected("quotedFieldName or ident")
+	}
+	return p.lex.Text, nil
+}
+
 // quotedFieldName = quote + fieldName + quote
 // TODO: Fixed according to defined documentation. Currently(2018.10.16) the reference lacks the spec.
 // See https://github.com/protocolbuffers/protobuf/issues/4558
@@ -53,6 +53,11 @@ func TestParser_ParseReserved(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 23,
+						Line:   1,
+						Column: 24,
+					},
 				},
 			},
 		},
@@ -70,6 +75,11 @@ func TestParser_ParseReserved(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 21,
+						Line:   1,
+						Column: 22,
+					},
 				},
 			},
 		},
@@ -89,6 +99,75 @@ func TestParser_ParseReserved(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 17,
+						Line:   1,
+						Column: 18,
+					},
+				},
+			},
+		},
+		{
+			name: "parsing ranges spread across multiple lines",
+			input: `reserved 2, 15,
+  9 to 11;`,
+			wantReserved: &parser.Reserved{
+				Ranges: []*parser.Range{
+					{
+						Begin: "2",
+					},
+					{
+						Begin: "15",
+					},
+					{
+						Begin: "9",
+						End:   "11",
+					},
+				},
+				Multiline: true,
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 25,
+						Line:   2,
+						Column: 10,
+					},
+				},
+			},
+		},
+		{
+			name:    "parsing an invalid; a quoted fieldName which isn't an ident",
+			input:   `reserved "foo", "1bar";`,
+			wantErr: true,
+		},
+		{
+			name:    "parsing an invalid; an empty quoted fieldName",
+			input:   `reserved '';`,
+			wantErr: true,
+		},
+		{
+			name:  "parsing fieldNames quoted by single quotes",
+			input: `reserved 'foo', "_bar2";`,
+			wantReserved: &parser.Reserved{
+				FieldNames: []string{
+					`'foo'`,
+					`"_bar2"`,
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 23,
+						Line:   1,
+						Column: 24,
+					},
 				},
 			},
 		},
@@ -121,3 +200,281 @@ func TestParser_ParseReserved(t *testing.T) {
 	}
 
 }
+
+func TestParser_ParseReserved_withSyntaxOrEdition(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		wantFieldNames []string
+		wantErr        bool
+	}{
+		{
+			name: "parsing unquoted fieldNames in the editions",
+			input: `edition = "2023";
+message Foo {
+  reserved foo, bar;
+}
+`,
+			wantFieldNames: []string{
+				"foo",
+				"bar",
+			},
+		},
+		{
+			name: "parsing mixed fieldNames in the editions",
+			input: `edition = "2023";
+message Foo {
+  reserved "foo", bar;
+}
+`,
+			wantFieldNames: []string{
+				`"foo"`,
+				"bar",
+			},
+		},
+		{
+			name: "parsing quoted fieldNames in proto3",
+			input: `syntax = "proto3";
+message Foo {
+  reserved "foo", "bar";
+}
+`,
+			wantFieldNames: []string{
+				`"foo"`,
+				`"bar"`,
+			},
+		},
+		{
+			name: "parsing an invalid; unquoted fieldNames in proto3",
+			input: `syntax = "proto3";
+message Foo {
+  reserved foo, bar;
+}
+`,
+			wantErr: true,
+		},
+		{
+			name: "parsing an invalid; unquoted fieldNames in proto2",
+			input: `syntax = "proto2";
+message Foo {
+  reserved foo;
+}
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			got, err := p.ParseProto()
+			switch {
+			case test.wantErr:
+				if err == nil {
+					t.Errorf("got err nil, but want err")
+				}
+				return
+			case !test.wantErr && err != nil:
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			message := got.ProtoBody[0].(*parser.Message)
+			reserved := message.MessageBody[0].(*parser.Reserved)
+			if !reflect.DeepEqual(reserved.FieldNames, test.wantFieldNames) {
+				t.Errorf("got %v, but want %v", reserved.FieldNames, test.wantFieldNames)
+			}
+		})
+	}
+}
+
+func TestParser_ParseMessage_reservedStatementsRoundTrip(t *testing.T) {
+	input := `message Foo {
+  reserved 1, 2, 3;
+  reserved 10 to 20,
+    30 to max;
+  reserved "foo", "bar";
+}`
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+	msg, err := p.ParseMessage()
+	if err != nil {
+		t.Fatalf("got err %v, but want nil", err)
+	}
+
+	lines := []string{"message " + msg.MessageName + " {"}
+	for _, body := range msg.MessageBody {
+		reserved, ok := body.(*parser.Reserved)
+		if !ok {
+			t.Fatalf("got %T, but want *parser.Reserved", body)
+		}
+
+		items := reserved.FieldNames
+		for _, r := range reserved.Ranges {
+			item := r.Begin
+			if r.End != "" {
+				item += " to " + r.End
+			}
+			items = append(items, item)
+		}
+		sep := ", "
+		if reserved.Multiline {
+			sep = ",\n    "
+		}
+		lines = append(lines, "  reserved "+strings.Join(items, sep)+";")
+	}
+	lines = append(lines, "}")
+
+	got := strings.Join(lines, "\n")
+	if got != input {
+		t.Errorf("got %q, but want %q", got, input)
+	}
+}
+
+func TestRange_Bounds(t *testing.T) {
+	tests := []struct {
+		name       string
+		inputRange *parser.Range
+		wantBegin  int
+		wantEnd    int
+		wantIsMax  bool
+		wantErr    bool
+	}{
+		{
+			name: "a single number",
+			inputRange: &parser.Range{
+				Begin: "2",
+			},
+			wantBegin: 2,
+			wantEnd:   2,
+		},
+		{
+			name: "a range",
+			inputRange: &parser.Range{
+				Begin: "9",
+				End:   "11",
+			},
+			wantBegin: 9,
+			wantEnd:   11,
+		},
+		{
+			name: "a range to max",
+			inputRange: &parser.Range{
+				Begin: "0x10",
+				End:   "max",
+			},
+			wantBegin: 16,
+			wantEnd:   536870911,
+			wantIsMax: true,
+		},
+		{
+			name: "an invalid number",
+			inputRange: &parser.Range{
+				Begin: "foo",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if test.inputRange.IsMax() != test.wantIsMax {
+				t.Errorf("got %v, but want %v", test.inputRange.IsMax(), test.wantIsMax)
+			}
+
+			begin, end, err := test.inputRange.Bounds()
+			switch {
+			case test.wantErr:
+				if err == nil {
+					t.Errorf("got err nil, but want err")
+				}
+				return
+			case !test.wantErr && err != nil:
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			if begin != test.wantBegin || end != test.wantEnd {
+				t.Errorf("got (%d, %d), but want (%d, %d)", begin, end, test.wantBegin, test.wantEnd)
+			}
+		})
+	}
+}
+
+func TestReserved_UnquotedFieldNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "unquoting the double-quoted and single-quoted fieldNames",
+			input: `reserved "foo", 'bar';`,
+			want:  []string{"foo", "bar"},
+		},
+		{
+			name:  "unquoting no fieldNames",
+			input: `reserved 1, 2;`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			reserved, err := p.ParseReserved()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := reserved.UnquotedFieldNames()
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, but want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParser_ParseReserved_mixed(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "parsing a number followed by a fieldName",
+			input:   `reserved 1, "foo";`,
+			wantErr: `expected [range, not a fieldName mixed with the ranges], found "\"foo\"" at <input>:1:13`,
+		},
+		{
+			name:    "parsing a fieldName followed by a number",
+			input:   `reserved "foo", 2 to 3;`,
+			wantErr: `expected [fieldName, not a range mixed with the fieldNames], found "2" at <input>:1:17`,
+		},
+		{
+			name: "parsing a fieldName followed by a number in a message",
+			input: `message Foo {
+  reserved "foo",
+    "bar", 3;
+}`,
+			wantErr: `expected [fieldName, not a range mixed with the fieldNames], found "3" at <input>:3:12`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			var err error
+			if strings.HasPrefix(test.input, "message") {
+				_, err = p.ParseMessage()
+			} else {
+				_, err = p.ParseReserved()
+			}
+			if err == nil || err.Error() != test.wantErr {
+				t.Errorf("got %v, but want %s", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Scope is the symbol table built by Resolve.
+type Scope struct {
+	// Symbols maps the fully-qualified names of the messages, groups and enums without the leading dot
+	// to their declarations, that is *Message, *GroupField and *Enum.
+	Symbols map[string]Visitee
+	// References maps the elements which refer to a message or an enum by name to the declarations.
+	// The keys are *Field, *MapField, *OneofField, *Extend, *RPCRequest and *RPCResponse.
+	// The elements of the scalar types and the unresolved ones aren't included.
+	References map[interface{}]Visitee
+
+	// namespaces is the set of the fully-qualified names which can contain symbols,
+	// that is the symbols and the package name with its prefixes.
+	namespaces map[string]struct{}
+}
+
+// Lookup returns the declaration of the fully-qualified name. The leading dot is optional.
+func (s *Scope) Lookup(name string) (Visitee, bool) {
+	symbol, ok := s.Symbols[strings.TrimPrefix(name, ".")]
+	return symbol, ok
+}
+
+// Resolve builds the symbol table of the proto and links the type names of the fields, the extends and the rpcs
+// to the messages and enums they refer to.
+// A relative name is searched from the innermost scope outwards, and a name with the leading dot is fully-qualified.
+//
+// Only the declarations in the proto are known, so a reference to a type defined in an imported file is
+// reported as an error along with the undefined ones. A type defined twice is reported as well.
+func Resolve(proto *Proto) (*Scope, []error) {
+	s := &Scope{
+		Symbols:    make(map[string]Visitee),
+		References: make(map[interface{}]Visitee),
+		namespaces: make(map[string]struct{}),
+	}
+
+	pkg := proto.PackageName()
+	if pkg != "" {
+		parts := strings.Split(pkg, ".")
+		for i := range parts {
+			s.namespaces[strings.Join(parts[:i+1], ".")] = struct{}{}
+		}
+	}
+
+	var errs []error
+	for _, body := range proto.ProtoBody {
+		errs = append(errs, s.define(pkg, body)...)
+	}
+	for _, body := range proto.ProtoBody {
+		errs = append(errs, s.resolveElement(pkg, body)...)
+	}
+	return s, errs
+}
+
+// define registers the types which the element declares in the scope.
+func (s *Scope) define(scope string, element Visitee) []error {
+	var errs []error
+	add := func(name string, symbol Visitee, body []Visitee) {
+		fullName := qualifyName(scope, name)
+		if first, ok := s.Symbols[fullName]; ok {
+			span, _ := spanOf(symbol)
+			firstSpan, _ := spanOf(first)
+			errs = append(errs, newValidationError(
+				span.meta.Pos,
+				"type %q is already defined at %s",
+				fullName, firstSpan.meta.Pos,
+			))
+			return
+		}
+		s.Symbols[fullName] = symbol
+		s.namespaces[fullName] = struct{}{}
+		for _, child := range body {
+			errs = append(errs, s.define(fullName, child)...)
+		}
+	}
+
+	switch e := element.(type) {
+	case *Message:
+		add(e.MessageName, e, e.MessageBody)
+	case *GroupField:
+		add(e.GroupName, e, e.MessageBody)
+	case *Enum:
+		add(e.EnumName, e, nil)
+	case *Extend:
+		// The groups in an extend belong to the scope enclosing the extend.
+		for _, child := range e.ExtendBody {
+			errs = append(errs, s.define(scope, child)...)
+		}
+	}
+	return errs
+}
+
+// resolveElement links the type names which the element and its children refer to.
+func (s *Scope) resolveElement(scope string, element Visitee) []error {
+	var errs []error
+	// link resolves the type name which the key refers to. An error is positioned at the node.
+	link := func(key interface{}, typeName string, what string, node Visitee) {
+		if IsScalarType(typeName) {
+			return
+		}
+		if symbol, ok := s.lookupRelative(scope, typeName); ok {
+			s.References[key] = symbol
+			return
+		}
+		span, _ := spanOf(node)
+		errs = append(errs, newValidationError(
+			span.meta.Pos,
+			"%s refers to the undefined type %q",
+			what, typeName,
+		))
+	}
+	resolveBody := func(scope string, body []Visitee) {
+		for _, child := range body {
+			errs = append(errs, s.resolveElement(scope, child)...)
+		}
+	}
+
+	switch e := element.(type) {
+	case *Message:
+		resolveBody(qualifyName(scope, e.MessageName), e.MessageBody)
+	case *GroupField:
+		resolveBody(qualifyName(scope, e.GroupName), e.MessageBody)
+	case *Field:
+		link(e, e.Type, fmt.Sprintf("field %q", e.FieldName), e)
+	case *MapField:
+		link(e, e.Type, fmt.Sprintf("map field %q", e.MapName), e)
+	case *Oneof:
+		for _, field := range e.OneofFields {
+			link(field, field.Type, fmt.Sprintf("field %q", field.FieldName), field)
+		}
+	case *Extend:
+		link(e, e.MessageType, "extend", e)
+		resolveBody(scope, e.ExtendBody)
+	case *Service:
+		for _, body := range e.ServiceBody {
+			rpc, ok := body.(*RPC)
+			if !ok {
+				continue
+			}
+			link(rpc.RPCRequest, rpc.RPCRequest.MessageType, fmt.Sprintf("rpc %q request", rpc.RPCName), rpc)
+			link(rpc.RPCResponse, rpc.RPCResponse.MessageType, fmt.Sprintf("rpc %q response", rpc.RPCName), rpc)
+		}
+	}
+	return errs
+}
+
+// lookupRelative resolves the type name referred in the scope like protoc.
+// The first component of a relative name is searched from the innermost scope outwards,
+// and the rest of the name is then resolved within the first scope which contains the component.
+func (s *Scope) lookupRelative(scope string, name string) (Visitee, bool) {
+	if strings.HasPrefix(name, ".") {
+		return s.Lookup(name)
+	}
+
+	first, rest := name, ""
+	if i := strings.Index(name, "."); 0 <= i {
+		first, rest = name[:i], name[i:]
+	}
+	for {
+		candidate := qualifyName(scope, first)
+		if _, ok := s.namespaces[candidate]; ok {
+			return s.Lookup(candidate + rest)
+		}
+		if scope == "" {
+			return nil, false
+		}
+		scope = parentScope(scope)
+	}
+}
+
+// parentScope returns the scope enclosing the scope, which is empty at the top level.
+func parentScope(scope string) string {
+	if i := strings.LastIndex(scope, "."); 0 <= i {
+		return scope[:i]
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
+)
+
+// ResolveImports finds and parses the files imported by the proto, searching each import path in the roots in order.
+// The public imports of an imported file are followed transitively, since their definitions are visible to the proto.
+// The result maps the unquoted import paths, like "foo/bar.proto", to the parsed files.
+//
+// An import which isn't found in any root, fails to be read or parsed, or forms a cycle of public imports
+// is reported as an error, and the other imports are resolved still. The well-known imports are searched as well,
+// so use Import.IsWellKnown to tell them from the missing files of the project.
+func ResolveImports(proto *Proto, roots []string) (map[string]*Proto, []error) {
+	r := &importResolver{
+		roots:  roots,
+		protos: make(map[string]*Proto),
+	}
+	for _, i := range proto.Imports() {
+		r.resolve(i)
+	}
+	return r.protos, r.errs
+}
+
+type importResolver struct {
+	roots  []string
+	protos map[string]*Proto
+	errs   []error
+
+	// importing is the chain of the import paths being resolved, which detects the cycles.
+	importing []string
+}
+
+func (r *importResolver) resolve(i *Import) {
+	path := i.UnquotedLocation()
+	for k, importing := range r.importing {
+		if importing == path {
+			cycle := append(append([]string(nil), r.importing[k:]...), path)
+			r.errs = append(r.errs, newValidationError(
+				i.Meta.Pos,
+				"import cycle %s",
+				strings.Join(cycle, " -> "),
+			))
+			return
+		}
+	}
+	if _, ok := r.protos[path]; ok {
+		return
+	}
+
+	proto, err := r.parse(i.Meta.Pos, path)
+	if err != nil {
+		r.errs = append(r.errs, err)
+		return
+	}
+	r.protos[path] = proto
+
+	r.importing = append(r.importing, path)
+	for _, child := range proto.Imports() {
+		if child.Modifier == ImportModifierPublic {
+			r.resolve(child)
+		}
+	}
+	r.importing = r.importing[:len(r.importing)-1]
+}
+
+// parse parses the file of the import path found first in the roots.
+func (r *importResolver) parse(pos meta.Position, path string) (*Proto, error) {
+	for _, root := range r.roots {
+		proto, err := ParseFile(filepath.Join(root, filepath.FromSlash(path)), WithPermissive(true))
+		var fileErr *FileError
+		if errors.As(err, &fileErr) && os.IsNotExist(fileErr.Err) {
+			continue
+		}
+		return proto, err
+	}
+	return nil, newValidationError(pos, "import %q was not found in %q", path, r.roots)
+}
@@ -0,0 +1,129 @@
+package parser_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestResolveImports(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		files     map[string]string
+		wantPaths []string
+		wantErrs  []string
+	}{
+		{
+			name: "resolving the imports in the roots",
+			input: `syntax = "proto3";
+import "a.proto";
+import weak "sub/b.proto";
+`,
+			files: map[string]string{
+				"root1/a.proto":     `syntax = "proto3"; import "c.proto";`,
+				"root2/a.proto":     `syntax = "proto3"; message Shadowed {}`,
+				"root2/sub/b.proto": `syntax = "proto3";`,
+				"root2/c.proto":     `syntax = "proto3";`,
+			},
+			wantPaths: []string{"a.proto", "sub/b.proto"},
+		},
+		{
+			name: "following the public imports transitively",
+			input: `syntax = "proto3";
+import "a.proto";
+`,
+			files: map[string]string{
+				"root1/a.proto": `syntax = "proto3"; import public "b.proto";`,
+				"root1/b.proto": `syntax = "proto3"; import public "c.proto";`,
+				"root2/c.proto": `syntax = "proto3";`,
+			},
+			wantPaths: []string{"a.proto", "b.proto", "c.proto"},
+		},
+		{
+			name: "reporting a cycle of the public imports",
+			input: `syntax = "proto3";
+import "a.proto";
+`,
+			files: map[string]string{
+				"root1/a.proto": `syntax = "proto3"; import public "b.proto";`,
+				"root1/b.proto": `syntax = "proto3"; import public "a.proto";`,
+			},
+			wantPaths: []string{"a.proto", "b.proto"},
+			wantErrs: []string{
+				`root1/b.proto:1:20: import cycle a.proto -> b.proto -> a.proto`,
+			},
+		},
+		{
+			name: "reporting the missing and the broken imports",
+			input: `syntax = "proto3";
+import "missing.proto";
+import "broken.proto";
+import "a.proto";
+`,
+			files: map[string]string{
+				"root1/broken.proto": `syntax = "proto3"; message {}`,
+				"root1/a.proto":      `syntax = "proto3";`,
+			},
+			wantPaths: []string{"a.proto"},
+			wantErrs: []string{
+				`<input>:2:1: import "missing.proto" was not found in ["root1" "root2"]`,
+				`expected [messageName], found "{" at root1/broken.proto:1:28`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "resolveImports")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			for name, content := range test.files {
+				path := filepath.Join(dir, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			proto, err := p.ParseProto()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			roots := []string{filepath.Join(dir, "root1"), filepath.Join(dir, "root2")}
+			got, errs := parser.ResolveImports(proto, roots)
+
+			var gotPaths []string
+			for path := range got {
+				gotPaths = append(gotPaths, path)
+			}
+			sort.Strings(gotPaths)
+			if !reflect.DeepEqual(gotPaths, test.wantPaths) {
+				t.Errorf("got %v, but want %v", gotPaths, test.wantPaths)
+			}
+
+			var gotErrs []string
+			for _, err := range errs {
+				gotErrs = append(gotErrs, strings.Replace(err.Error(), dir+string(filepath.Separator), "", -1))
+			}
+			if !reflect.DeepEqual(gotErrs, test.wantErrs) {
+				t.Errorf("got %q, but want %q", gotErrs, test.wantErrs)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package parser_test
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		wantSymbols    []string
+		wantReferences map[string]string
+		wantErrs       []string
+	}{
+		{
+			name: "resolving names in nested scopes",
+			input: `syntax = "proto3";
+package foo.bar;
+message SearchResponse {
+  message Result {
+    string url = 1;
+    Status status = 2;
+  }
+  enum Status {
+    OK = 0;
+  }
+  repeated Result results = 1;
+  .foo.bar.SearchResponse.Result top = 2;
+  bar.Other other = 3;
+  oneof choice {
+    SearchResponse.Status chosen_status = 4;
+  }
+  map<string, Result> by_url = 5;
+}
+message Other {
+  SearchResponse.Result result = 1;
+}
+service SearchService {
+  rpc Search(Other) returns (stream foo.bar.SearchResponse);
+}
+`,
+			wantSymbols: []string{
+				"foo.bar.Other",
+				"foo.bar.SearchResponse",
+				"foo.bar.SearchResponse.Result",
+				"foo.bar.SearchResponse.Status",
+			},
+			wantReferences: map[string]string{
+				"status":          "foo.bar.SearchResponse.Status",
+				"results":         "foo.bar.SearchResponse.Result",
+				"top":             "foo.bar.SearchResponse.Result",
+				"other":           "foo.bar.Other",
+				"chosen_status":   "foo.bar.SearchResponse.Status",
+				"by_url":          "foo.bar.SearchResponse.Result",
+				"result":          "foo.bar.SearchResponse.Result",
+				"Search request":  "foo.bar.Other",
+				"Search response": "foo.bar.SearchResponse",
+			},
+		},
+		{
+			name: "resolving groups and extends in proto2",
+			input: `syntax = "proto2";
+message Foo {
+  optional group Result = 1 {
+    optional Kind kind = 2;
+  }
+  enum Kind {
+    A = 0;
+  }
+  extensions 100 to 199;
+}
+extend Foo {
+  optional Foo.Result extra = 100;
+}
+`,
+			wantSymbols: []string{
+				"Foo",
+				"Foo.Kind",
+				"Foo.Result",
+			},
+			wantReferences: map[string]string{
+				"kind":   "Foo.Kind",
+				"extend": "Foo",
+				"extra":  "Foo.Result",
+			},
+		},
+		{
+			name: "reporting undefined and duplicated types",
+			input: `syntax = "proto3";
+package pkg;
+import "google/protobuf/timestamp.proto";
+message Foo {
+  message Inner {}
+  google.protobuf.Timestamp created = 1;
+  Inner.Missing missing = 2;
+}
+message Bar {
+  Inner inner = 1;
+}
+enum Foo {
+  A = 0;
+}
+service S {
+  rpc Get(Foo) returns (Unknown);
+}
+`,
+			wantSymbols: []string{
+				"pkg.Bar",
+				"pkg.Foo",
+				"pkg.Foo.Inner",
+			},
+			wantReferences: map[string]string{
+				"Get request": "pkg.Foo",
+			},
+			wantErrs: []string{
+				`<input>:12:1: type "pkg.Foo" is already defined at <input>:4:1`,
+				`<input>:6:3: field "created" refers to the undefined type "google.protobuf.Timestamp"`,
+				`<input>:7:3: field "missing" refers to the undefined type "Inner.Missing"`,
+				`<input>:10:3: field "inner" refers to the undefined type "Inner"`,
+				`<input>:16:3: rpc "Get" response refers to the undefined type "Unknown"`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			proto, err := p.ParseProto()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			scope, errs := parser.Resolve(proto)
+
+			var gotSymbols []string
+			for name := range scope.Symbols {
+				gotSymbols = append(gotSymbols, name)
+			}
+			sort.Strings(gotSymbols)
+			if !reflect.DeepEqual(gotSymbols, test.wantSymbols) {
+				t.Errorf("got symbols %v, but want %v", gotSymbols, test.wantSymbols)
+			}
+
+			gotReferences := make(map[string]string)
+			for key, symbol := range scope.References {
+				gotReferences[referenceName(proto, key)] = symbolName(t, scope, symbol)
+			}
+			if !reflect.DeepEqual(gotReferences, test.wantReferences) {
+				t.Errorf("got references %v, but want %v", gotReferences, test.wantReferences)
+			}
+
+			var gotErrs []string
+			for _, e := range errs {
+				gotErrs = append(gotErrs, e.Error())
+			}
+			if !reflect.DeepEqual(gotErrs, test.wantErrs) {
+				t.Errorf("got errs %v, but want %v", gotErrs, test.wantErrs)
+			}
+		})
+	}
+}
+
+// referenceName names the element which refers to a type to compare it in the test.
+func referenceName(proto *parser.Proto, key interface{}) string {
+	switch k := key.(type) {
+	case *parser.Field:
+		return k.FieldName
+	case *parser.MapField:
+		return k.MapName
+	case *parser.OneofField:
+		return k.FieldName
+	case *parser.Extend:
+		return "extend"
+	}
+
+	for _, body := range proto.ProtoBody {
+		service, ok := body.(*parser.Service)
+		if !ok {
+			continue
+		}
+		for _, element := range service.ServiceBody {
+			rpc, ok := element.(*parser.RPC)
+			if !ok {
+				continue
+			}
+			switch key {
+			case rpc.RPCRequest:
+				return rpc.RPCName + " request"
+			case rpc.RPCResponse:
+				return rpc.RPCName + " response"
+			}
+		}
+	}
+	return ""
+}
+
+func symbolName(t *testing.T, scope *parser.Scope, symbol parser.Visitee) string {
+	for name, s := range scope.Symbols {
+		if s == symbol {
+			return name
+		}
+	}
+	t.Fatalf("got an unknown symbol %v", symbol)
+	return ""
+}
@@ -0,0 +1,116 @@
+package parser
+
+// RewriteOptions calls f for every option in the proto, allowing f to modify it in place.
+// Options of fields, map fields, oneof fields and enum fields are passed to f as transient Options
+// carrying their OptionName and Constant, which are written back after f returns.
+func RewriteOptions(proto *Proto, f func(o *Option)) {
+	proto.Accept(&optionRewriter{f: f})
+}
+
+type optionRewriter struct {
+	f func(o *Option)
+}
+
+func (r *optionRewriter) rewrite(optionName, constant *string) {
+	o := &Option{
+		OptionName: *optionName,
+		Constant:   *constant,
+	}
+	r.f(o)
+	*optionName = o.OptionName
+	*constant = o.Constant
+}
+
+func (r *optionRewriter) rewriteFieldOptions(options []*FieldOption) {
+	for _, option := range options {
+		r.rewrite(&option.OptionName, &option.Constant)
+	}
+}
+
+func (r *optionRewriter) VisitComment(*Comment) {}
+
+func (r *optionRewriter) VisitEdition(*Edition) bool {
+	return false
+}
+
+func (r *optionRewriter) VisitEmptyStatement(*EmptyStatement) bool {
+	return false
+}
+
+func (r *optionRewriter) VisitEnum(*Enum) bool {
+	return true
+}
+
+func (r *optionRewriter) VisitEnumField(e *EnumField) bool {
+	for _, option := range e.EnumValueOptions {
+		r.rewrite(&option.OptionName, &option.Constant)
+	}
+	return false
+}
+
+func (r *optionRewriter) VisitExtend(*Extend) bool {
+	return true
+}
+
+func (r *optionRewriter) VisitExtensions(*Extensions) bool {
+	return false
+}
+
+func (r *optionRewriter) VisitField(f *Field) bool {
+	r.rewriteFieldOptions(f.FieldOptions)
+	return false
+}
+
+func (r *optionRewriter) VisitGroupField(*GroupField) bool {
+	return true
+}
+
+func (r *optionRewriter) VisitImport(*Import) bool {
+	return false
+}
+
+func (r *optionRewriter) VisitMapField(m *MapField) bool {
+	r.rewriteFieldOptions(m.FieldOptions)
+	return false
+}
+
+func (r *optionRewriter) VisitMessage(*Message) bool {
+	return true
+}
+
+func (r *optionRewriter) VisitOneof(*Oneof) bool {
+	return true
+}
+
+func (r *optionRewriter) VisitOneofField(f *OneofField) bool {
+	r.rewriteFieldOptions(f.FieldOptions)
+	return false
+}
+
+func (r *optionRewriter) VisitOption(o *Option) bool {
+	r.f(o)
+	return false
+}
+
+func (r *optionRewriter) VisitPackage(*Package) bool {
+	return false
+}
+
+func (r *optionRewriter) VisitReserved(*Reserved) bool {
+	return false
+}
+
+func (r *optionRewriter) VisitRPC(rpc *RPC) bool {
+	for _, option := range rpc.Options {
+		r.f(option)
+	}
+	return false
+}
+
+func (r *optionRewriter) VisitService(*Service) bool {
+	return true
+}
+
+func (r *optionRewriter) VisitSyntax(*Syntax) bool {
+	return false
+}
@@ -0,0 +1,77 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestRewriteOptions(t *testing.T) {
+	input := `
+syntax = "proto3";
+option deprecated = false;
+enum Enum {
+  option deprecated = false;
+  UNKNOWN = 0 [deprecated = false];
+}
+message Message {
+  option deprecated = false;
+  string field = 1 [deprecated = false, json_name = "f"];
+  map<string, string> map_field = 2 [deprecated = false];
+  oneof oneof {
+    string oneof_field = 3 [deprecated = false];
+  }
+}
+service Service {
+  option deprecated = false;
+  rpc Method (Request) returns (Response) {
+    option deprecated = false;
+  };
+}
+`
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+	proto, err := p.ParseProto()
+	if err != nil {
+		t.Fatalf("got err %v, but want nil", err)
+	}
+
+	var rewritten []string
+	parser.RewriteOptions(proto, func(o *parser.Option) {
+		if o.OptionName == "deprecated" && o.Constant == "false" {
+			o.Constant = "true"
+			rewritten = append(rewritten, o.OptionName)
+		}
+	})
+	if len(rewritten) != 9 {
+		t.Errorf("got %d rewritten options, but want 9", len(rewritten))
+	}
+
+	var got []string
+	parser.RewriteOptions(proto, func(o *parser.Option) {
+		got = append(got, o.OptionName+"="+o.Constant)
+	})
+	want := []string{
+		"deprecated=true",
+		"deprecated=true",
+		"deprecated=true",
+		"deprecated=true",
+		"deprecated=true",
+		`json_name="f"`,
+		"deprecated=true",
+		"deprecated=true",
+		"deprecated=true",
+		"deprecated=true",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, but want %v", got, want)
+	}
+
+	message := proto.ProtoBody[2].(*parser.Message)
+	field := message.MessageBody[1].(*parser.Field)
+	if field.FieldOptions[0].Constant != "true" {
+		t.Errorf("got %s, but want true", field.FieldOptions[0].Constant)
+	}
+}
@@ -0,0 +1,41 @@
+package parser
+
+import "sort"
+
+// scalarTypes is the set of the scalar value types built into protobuf.
+// It's read by every parser, so it must not be modified.
+var scalarTypes = map[string]struct{}{
+	"double":   {},
+	"float":    {},
+	"int32":    {},
+	"int64":    {},
+	"uint32":   {},
+	"uint64":   {},
+	"sint32":   {},
+	"sint64":   {},
+	"fixed32":  {},
+	"fixed64":  {},
+	"sfixed32": {},
+	"sfixed64": {},
+	"bool":     {},
+	"string":   {},
+	"bytes":    {},
+}
+
+// IsScalarType reports whether the name is one of the scalar value types built into protobuf, like "int32" or "bytes".
+// The parser reads such a type as a scalar type and any other one as a message or an enum type.
+func IsScalarType(name string) bool {
+	_, ok := scalarTypes[name]
+	return ok
+}
+
+// ScalarTypes returns the names of the scalar value types built into protobuf in the alphabetical order.
+// The slice is newly allocated on each call, so the caller may modify it.
+func ScalarTypes() []string {
+	names := make([]string, 0, len(scalarTypes))
+	for name := range scalarTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,107 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestIsScalarType(t *testing.T) {
+	tests := []struct {
+		name       string
+		typeName   string
+		wantScalar bool
+	}{
+		{
+			name:       "checking a varint type",
+			typeName:   "sint64",
+			wantScalar: true,
+		},
+		{
+			name:       "checking a fixed type",
+			typeName:   "sfixed32",
+			wantScalar: true,
+		},
+		{
+			name:       "checking bytes",
+			typeName:   "bytes",
+			wantScalar: true,
+		},
+		{
+			name:     "checking a message type",
+			typeName: "google.protobuf.Timestamp",
+		},
+		{
+			name:     "checking a type which differs in the case",
+			typeName: "Int32",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if got := parser.IsScalarType(test.typeName); got != test.wantScalar {
+				t.Errorf("got %v, but want %v", got, test.wantScalar)
+			}
+		})
+	}
+}
+
+func TestScalarTypes(t *testing.T) {
+	want := []string{
+		"bool", "bytes", "double", "fixed32", "fixed64", "float", "int32", "int64",
+		"sfixed32", "sfixed64", "sint32", "sint64", "string", "uint32", "uint64",
+	}
+
+	got := parser.ScalarTypes()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, but want %v", got, want)
+	}
+
+	got[0] = "boolean"
+	if !parser.IsScalarType("bool") || parser.IsScalarType("boolean") {
+		t.Errorf("got the scalar types modified through the returned slice")
+	}
+	if again := parser.ScalarTypes(); !reflect.DeepEqual(again, want) {
+		t.Errorf("got %v, but want %v", again, want)
+	}
+}
+
+func TestField_IsScalar(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantScalar bool
+	}{
+		{
+			name:       "parsing a scalar field",
+			input:      "repeated fixed64 a = 1;",
+			wantScalar: true,
+		},
+		{
+			name:  "parsing a message field",
+			input: "foo.Bar a = 1;",
+		},
+		{
+			name:  "parsing a field whose type starts with a scalar type",
+			input: "int32s a = 1;",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			field, err := p.ParseField()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := field.IsScalar(); got != test.wantScalar {
+				t.Errorf("got %v, but want %v", got, test.wantScalar)
+			}
+		})
+	}
+}
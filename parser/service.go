@@ -57,6 +57,17 @@ func (r *RPC) Accept(v Visitor) {
 	}
 }
 
+// IdempotencyLevel returns the value of the idempotency_level option, like "NO_SIDE_EFFECTS" or "IDEMPOTENT".
+// The second return value reports whether the option is set.
+func (r *RPC) IdempotencyLevel() (string, bool) {
+	for _, option := range r.Options {
+		if option.OptionName == "idempotency_level" {
+			return option.Constant, true
+		}
+	}
+	return "", false
+}
+
 // Service consists of RPCs.
 type Service struct {
 	ServiceName string
@@ -196,6 +207,7 @@ func (p *Parser) parseServiceBody() (
 			if err != nil {
 				return nil, nil, scanner.Position{}, err
 			}
+			stmt = newEmptyStatement(p.lex.Pos)
 		}
 
 		p.MaybeScanInlineComment(stmt)
@@ -299,7 +311,10 @@ func (p *Parser) parseRPCRequest() (*RPCRequest, error) {
 	return &RPCRequest{
 		IsStream:    isStream,
 		MessageType: messageType,
-		Meta:        meta.Meta{Pos: startPos.Position},
+		Meta: meta.Meta{
+			Pos:     startPos.Position,
+			LastPos: p.lex.Pos.Position,
+		},
 	}, nil
 }
 
@@ -332,7 +347,10 @@ func (p *Parser) parseRPCResponse() (*RPCResponse, error) {
 	return &RPCResponse{
 		IsStream:    isStream,
 		MessageType: messageType,
-		Meta:        meta.Meta{Pos: startPos.Position},
+		Meta: meta.Meta{
+			Pos:     startPos.Position,
+			LastPos: p.lex.Pos.Position,
+		},
 	}, nil
 }
 
@@ -44,6 +44,11 @@ service SearchService {
 									Line:   3,
 									Column: 14,
 								},
+								LastPos: meta.Position{
+									Offset: 52,
+									Line:   3,
+									Column: 28,
+								},
 							},
 						},
 						RPCResponse: &parser.RPCResponse{
@@ -54,6 +59,11 @@ service SearchService {
 									Line:   3,
 									Column: 38,
 								},
+								LastPos: meta.Position{
+									Offset: 77,
+									Line:   3,
+									Column: 53,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -104,6 +114,11 @@ service SearchService {
 									Line:   3,
 									Column: 14,
 								},
+								LastPos: meta.Position{
+									Offset: 52,
+									Line:   3,
+									Column: 28,
+								},
 							},
 						},
 						RPCResponse: &parser.RPCResponse{
@@ -114,6 +129,11 @@ service SearchService {
 									Line:   3,
 									Column: 38,
 								},
+								LastPos: meta.Position{
+									Offset: 77,
+									Line:   3,
+									Column: 53,
+								},
 							},
 						},
 						Options: []*parser.Option{
@@ -126,6 +146,11 @@ service SearchService {
 										Line:   3,
 										Column: 57,
 									},
+									LastPos: meta.Position{
+										Offset: 108,
+										Line:   3,
+										Column: 84,
+									},
 								},
 							},
 						},
@@ -180,6 +205,11 @@ service SearchService {
 									Line:   3,
 									Column: 14,
 								},
+								LastPos: meta.Position{
+									Offset: 52,
+									Line:   3,
+									Column: 28,
+								},
 							},
 						},
 						RPCResponse: &parser.RPCResponse{
@@ -190,6 +220,11 @@ service SearchService {
 									Line:   3,
 									Column: 38,
 								},
+								LastPos: meta.Position{
+									Offset: 77,
+									Line:   3,
+									Column: 53,
+								},
 							},
 						},
 						Options: []*parser.Option{
@@ -202,6 +237,11 @@ service SearchService {
 										Line:   4,
 										Column: 2,
 									},
+									LastPos: meta.Position{
+										Offset: 110,
+										Line:   4,
+										Column: 29,
+									},
 								},
 							},
 							{
@@ -213,6 +253,11 @@ service SearchService {
 										Line:   5,
 										Column: 2,
 									},
+									LastPos: meta.Position{
+										Offset: 142,
+										Line:   5,
+										Column: 30,
+									},
 								},
 							},
 						},
@@ -269,6 +314,11 @@ service ItemService {
 									Line:   5,
 									Column: 23,
 								},
+								LastPos: meta.Position{
+									Offset: 171,
+									Line:   5,
+									Column: 45,
+								},
 							},
 						},
 						RPCResponse: &parser.RPCResponse{
@@ -279,6 +329,11 @@ service ItemService {
 									Line:   5,
 									Column: 55,
 								},
+								LastPos: meta.Position{
+									Offset: 212,
+									Line:   5,
+									Column: 86,
+								},
 							},
 						},
 						Comments: []*parser.Comment{
@@ -290,6 +345,11 @@ service ItemService {
 										Line:   4,
 										Column: 5,
 									},
+									LastPos: meta.Position{
+										Offset: 125,
+										Line:   4,
+										Column: 58,
+									},
 								},
 							},
 						},
@@ -316,6 +376,11 @@ service ItemService {
 									Line:   8,
 									Column: 23,
 								},
+								LastPos: meta.Position{
+									Offset: 321,
+									Line:   8,
+									Column: 45,
+								},
 							},
 						},
 						RPCResponse: &parser.RPCResponse{
@@ -326,6 +391,11 @@ service ItemService {
 									Line:   8,
 									Column: 55,
 								},
+								LastPos: meta.Position{
+									Offset: 351,
+									Line:   8,
+									Column: 75,
+								},
 							},
 						},
 						Comments: []*parser.Comment{
@@ -337,6 +407,11 @@ service ItemService {
 										Line:   7,
 										Column: 5,
 									},
+									LastPos: meta.Position{
+										Offset: 275,
+										Line:   7,
+										Column: 58,
+									},
 								},
 							},
 						},
@@ -388,6 +463,11 @@ service SearchService { // TODO: Search is not implemented yet.
 									Line:   3,
 									Column: 14,
 								},
+								LastPos: meta.Position{
+									Offset: 92,
+									Line:   3,
+									Column: 28,
+								},
 							},
 						},
 						RPCResponse: &parser.RPCResponse{
@@ -398,6 +478,11 @@ service SearchService { // TODO: Search is not implemented yet.
 									Line:   3,
 									Column: 38,
 								},
+								LastPos: meta.Position{
+									Offset: 117,
+									Line:   3,
+									Column: 53,
+								},
 							},
 						},
 						InlineComment: &parser.Comment{
@@ -408,6 +493,11 @@ service SearchService { // TODO: Search is not implemented yet.
 									Line:   3,
 									Column: 56,
 								},
+								LastPos: meta.Position{
+									Offset: 142,
+									Line:   3,
+									Column: 78,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -432,6 +522,11 @@ service SearchService { // TODO: Search is not implemented yet.
 							Line:   2,
 							Column: 25,
 						},
+						LastPos: meta.Position{
+							Offset: 63,
+							Line:   2,
+							Column: 63,
+						},
 					},
 				},
 				Meta: meta.Meta{
@@ -469,6 +564,11 @@ service SearchService {
 									Line:   3,
 									Column: 14,
 								},
+								LastPos: meta.Position{
+									Offset: 52,
+									Line:   3,
+									Column: 28,
+								},
 							},
 						},
 						RPCResponse: &parser.RPCResponse{
@@ -479,6 +579,11 @@ service SearchService {
 									Line:   3,
 									Column: 38,
 								},
+								LastPos: meta.Position{
+									Offset: 77,
+									Line:   3,
+									Column: 53,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -532,6 +637,11 @@ service SearchService {
 									Line:   3,
 									Column: 14,
 								},
+								LastPos: meta.Position{
+									Offset: 52,
+									Line:   3,
+									Column: 28,
+								},
 							},
 						},
 						RPCResponse: &parser.RPCResponse{
@@ -542,6 +652,11 @@ service SearchService {
 									Line:   3,
 									Column: 38,
 								},
+								LastPos: meta.Position{
+									Offset: 77,
+									Line:   3,
+									Column: 53,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -565,6 +680,11 @@ service SearchService {
 								Line:   4,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 102,
+								Line:   4,
+								Column: 23,
+							},
 						},
 					},
 					&parser.Comment{
@@ -575,6 +695,11 @@ service SearchService {
 								Line:   5,
 								Column: 3,
 							},
+							LastPos: meta.Position{
+								Offset: 130,
+								Line:   5,
+								Column: 27,
+							},
 						},
 					},
 				},
@@ -613,6 +738,11 @@ service SearchService {
 									Line:   3,
 									Column: 14,
 								},
+								LastPos: meta.Position{
+									Offset: 52,
+									Line:   3,
+									Column: 28,
+								},
 							},
 						},
 						RPCResponse: &parser.RPCResponse{
@@ -623,6 +753,11 @@ service SearchService {
 									Line:   3,
 									Column: 38,
 								},
+								LastPos: meta.Position{
+									Offset: 77,
+									Line:   3,
+									Column: 53,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -674,6 +809,11 @@ service SearchService {
 									Line:   3,
 									Column: 14,
 								},
+								LastPos: meta.Position{
+									Offset: 52,
+									Line:   3,
+									Column: 28,
+								},
 							},
 						},
 						RPCResponse: &parser.RPCResponse{
@@ -684,6 +824,11 @@ service SearchService {
 									Line:   3,
 									Column: 38,
 								},
+								LastPos: meta.Position{
+									Offset: 77,
+									Line:   3,
+									Column: 53,
+								},
 							},
 						},
 						Meta: meta.Meta{
@@ -714,6 +859,115 @@ service SearchService {
 				},
 			},
 		},
+		{
+			name: "parsing an empty statement",
+			input: `
+service SearchService {
+  ;
+}
+`,
+			wantService: &parser.Service{
+				ServiceName: "SearchService",
+				ServiceBody: []parser.Visitee{
+					&parser.EmptyStatement{
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 27,
+								Line:   3,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 27,
+								Line:   3,
+								Column: 3,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 1,
+						Line:   2,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 29,
+						Line:   4,
+						Column: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "parsing rpcs with fully qualified message types",
+			input: `service S {
+  rpc F(.foo.bar.Request) returns (stream baz.qux.v1.Response);
+}
+`,
+			wantService: &parser.Service{
+				ServiceName: "S",
+				ServiceBody: []parser.Visitee{
+					&parser.RPC{
+						RPCName: "F",
+						RPCRequest: &parser.RPCRequest{
+							MessageType: ".foo.bar.Request",
+							Meta: meta.Meta{
+								Pos: meta.Position{
+									Offset: 19,
+									Line:   2,
+									Column: 8,
+								},
+								LastPos: meta.Position{
+									Offset: 36,
+									Line:   2,
+									Column: 25,
+								},
+							},
+						},
+						RPCResponse: &parser.RPCResponse{
+							IsStream:    true,
+							MessageType: "baz.qux.v1.Response",
+							Meta: meta.Meta{
+								Pos: meta.Position{
+									Offset: 46,
+									Line:   2,
+									Column: 35,
+								},
+								LastPos: meta.Position{
+									Offset: 73,
+									Line:   2,
+									Column: 62,
+								},
+							},
+						},
+						Meta: meta.Meta{
+							Pos: meta.Position{
+								Offset: 14,
+								Line:   2,
+								Column: 3,
+							},
+							LastPos: meta.Position{
+								Offset: 74,
+								Line:   2,
+								Column: 63,
+							},
+						},
+					},
+				},
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					LastPos: meta.Position{
+						Offset: 76,
+						Line:   3,
+						Column: 1,
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -747,3 +1001,117 @@ service SearchService {
 	}
 
 }
+
+func TestRPC_IdempotencyLevel(t *testing.T) {
+	tests := []struct {
+		name                 string
+		input                string
+		wantIdempotencyLevel string
+		wantOK               bool
+	}{
+		{
+			name:  "parsing a rpc without options",
+			input: `rpc Get (GetRequest) returns (GetResponse);`,
+		},
+		{
+			name: "parsing a rpc without idempotency_level",
+			input: `rpc Get (GetRequest) returns (GetResponse) {
+  option deprecated = true;
+}`,
+		},
+		{
+			name: "parsing a rpc with idempotency_level",
+			input: `rpc Get (GetRequest) returns (GetResponse) {
+  option deprecated = true;
+  option idempotency_level = NO_SIDE_EFFECTS;
+}`,
+			wantIdempotencyLevel: "NO_SIDE_EFFECTS",
+			wantOK:               true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader("service S {\n" + test.input + "\n}")))
+			service, err := p.ParseService()
+			if err != nil {
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			got, ok := service.ServiceBody[0].(*parser.RPC).IdempotencyLevel()
+			if got != test.wantIdempotencyLevel || ok != test.wantOK {
+				t.Errorf("got (%q, %v), but want (%q, %v)", got, ok, test.wantIdempotencyLevel, test.wantOK)
+			}
+		})
+	}
+}
+
+func TestParser_ParseService_streaming(t *testing.T) {
+	tests := []struct {
+		name               string
+		inputRPC           string
+		wantRequestStream  bool
+		wantResponseStream bool
+		wantErr            bool
+	}{
+		{
+			name:     "parsing an unary rpc",
+			inputRPC: "rpc F(Req) returns (Resp);",
+		},
+		{
+			name:              "parsing a client-streaming rpc",
+			inputRPC:          "rpc F(stream Req) returns (Resp);",
+			wantRequestStream: true,
+		},
+		{
+			name:               "parsing a server-streaming rpc",
+			inputRPC:           "rpc F(Req) returns (stream Resp);",
+			wantResponseStream: true,
+		},
+		{
+			name:               "parsing a bidirectional-streaming rpc",
+			inputRPC:           "rpc F(stream Req) returns (stream Resp);",
+			wantRequestStream:  true,
+			wantResponseStream: true,
+		},
+		{
+			name:     "parsing an invalid request stream without a type",
+			inputRPC: "rpc F(stream) returns (Resp);",
+			wantErr:  true,
+		},
+		{
+			name:     "parsing an invalid response stream without a type",
+			inputRPC: "rpc F(Req) returns (stream);",
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			input := "service S {\n  " + test.inputRPC + "\n}"
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+			got, err := p.ParseService()
+			switch {
+			case test.wantErr:
+				if err == nil {
+					t.Errorf("got err nil, but want err")
+				}
+				return
+			case !test.wantErr && err != nil:
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			rpc := got.ServiceBody[0].(*parser.RPC)
+			if rpc.RPCRequest.IsStream != test.wantRequestStream {
+				t.Errorf("got %v, but want %v", rpc.RPCRequest.IsStream, test.wantRequestStream)
+			}
+			if rpc.RPCResponse.IsStream != test.wantResponseStream {
+				t.Errorf("got %v, but want %v", rpc.RPCResponse.IsStream, test.wantResponseStream)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package parser
+
+import (
+	"sort"
+	"strconv"
+)
+
+// SortDeclarations reorders the declarations of the proto into a canonical order, which makes diffs stable.
+// It only changes the textual order, not the wire semantics.
+//
+// The top-level declarations are ordered as imports, packages and options in their original order,
+// then messages, enums and services alphabetically, followed by extends.
+// In a message, options come first, followed by reserved and extensions, fields by number,
+// and nested messages and enums alphabetically. A oneof is placed by its smallest field number.
+// Fields in oneofs, extends and groups are ordered by number, and so are the values in enums.
+//
+// The attached comments move together with their declarations. The last comments of a body,
+// included by WithBodyIncludingComments, stay at the end.
+// The sort is stable, so sorting an already sorted proto doesn't change it.
+func SortDeclarations(proto *Proto) {
+	proto.ProtoBody = sortBody(proto.ProtoBody, protoBodySortKey)
+}
+
+// sortKey orders the elements of a body by rank, then by name and number.
+type sortKey struct {
+	rank   int
+	name   string
+	number int64
+}
+
+func (k sortKey) less(other sortKey) bool {
+	if k.rank != other.rank {
+		return k.rank < other.rank
+	}
+	if k.name != other.name {
+		return k.name < other.name
+	}
+	return k.number < other.number
+}
+
+// sortBody sorts the body stably by the keys. Each key function sorts the nested bodies of the element as well.
+func sortBody(body []Visitee, key func(Visitee) sortKey) []Visitee {
+	var elements []Visitee
+	var keys []sortKey
+	var comments []Visitee
+	for _, element := range body {
+		if _, ok := element.(*Comment); ok {
+			comments = append(comments, element)
+			continue
+		}
+		elements = append(elements, element)
+		keys = append(keys, key(element))
+	}
+
+	sort.Stable(&sortableBody{elements: elements, keys: keys})
+	return append(elements, comments...)
+}
+
+type sortableBody struct {
+	elements []Visitee
+	keys     []sortKey
+}
+
+func (b *sortableBody) Len() int           { return len(b.elements) }
+func (b *sortableBody) Less(i, j int) bool { return b.keys[i].less(b.keys[j]) }
+func (b *sortableBody) Swap(i, j int) {
+	b.elements[i], b.elements[j] = b.elements[j], b.elements[i]
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+}
+
+func protoBodySortKey(element Visitee) sortKey {
+	switch e := element.(type) {
+	case *Message:
+		e.MessageBody = sortBody(e.MessageBody, messageBodySortKey)
+		return sortKey{rank: 1, name: e.MessageName}
+	case *Enum:
+		e.EnumBody = sortBody(e.EnumBody, enumBodySortKey)
+		return sortKey{rank: 2, name: e.EnumName}
+	case *Service:
+		return sortKey{rank: 3, name: e.ServiceName}
+	case *Extend:
+		e.ExtendBody = sortBody(e.ExtendBody, extendBodySortKey)
+		return sortKey{rank: 4}
+	default:
+		return sortKey{}
+	}
+}
+
+func messageBodySortKey(element Visitee) sortKey {
+	switch e := element.(type) {
+	case *Option:
+		return sortKey{}
+	case *Reserved, *Extensions:
+		return sortKey{rank: 1}
+	case *Field:
+		return sortKey{rank: 2, number: parseSortNumber(e.FieldNumber)}
+	case *MapField:
+		return sortKey{rank: 2, number: parseSortNumber(e.FieldNumber)}
+	case *GroupField:
+		e.MessageBody = sortBody(e.MessageBody, messageBodySortKey)
+		return sortKey{rank: 2, number: parseSortNumber(e.FieldNumber)}
+	case *Oneof:
+		sort.SliceStable(e.OneofFields, func(i, j int) bool {
+			return parseSortNumber(e.OneofFields[i].FieldNumber) < parseSortNumber(e.OneofFields[j].FieldNumber)
+		})
+		if len(e.OneofFields) == 0 {
+			return sortKey{rank: 2}
+		}
+		return sortKey{rank: 2, number: parseSortNumber(e.OneofFields[0].FieldNumber)}
+	case *Message:
+		e.MessageBody = sortBody(e.MessageBody, messageBodySortKey)
+		return sortKey{rank: 3, name: e.MessageName}
+	case *Enum:
+		e.EnumBody = sortBody(e.EnumBody, enumBodySortKey)
+		return sortKey{rank: 4, name: e.EnumName}
+	case *Extend:
+		e.ExtendBody = sortBody(e.ExtendBody, extendBodySortKey)
+		return sortKey{rank: 5}
+	default:
+		return sortKey{rank: 5}
+	}
+}
+
+func enumBodySortKey(element Visitee) sortKey {
+	switch e := element.(type) {
+	case *Option:
+		return sortKey{}
+	case *Reserved:
+		return sortKey{rank: 1}
+	case *EnumField:
+		return sortKey{rank: 2, number: parseSortNumber(e.Number)}
+	default:
+		return sortKey{rank: 3}
+	}
+}
+
+func extendBodySortKey(element Visitee) sortKey {
+	switch e := element.(type) {
+	case *Field:
+		return sortKey{number: parseSortNumber(e.FieldNumber)}
+	case *GroupField:
+		e.MessageBody = sortBody(e.MessageBody, messageBodySortKey)
+		return sortKey{number: parseSortNumber(e.FieldNumber)}
+	default:
+		return sortKey{rank: 1}
+	}
+}
+
+// parseSortNumber parses a field number or an enum value, returning 0 if it is malformed.
+func parseSortNumber(s string) int64 {
+	n, _ := strconv.ParseInt(s, 0, 64)
+	return n
+}
@@ -0,0 +1,161 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/internal/util_test"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestSortDeclarations(t *testing.T) {
+	tests := []struct {
+		name                       string
+		input                      string
+		inputBodyIncludingComments bool
+		wantOrder                  []string
+	}{
+		{
+			name: "sorting an unsorted proto",
+			input: `
+syntax = "proto2";
+package foo;
+service Zoo {}
+// Comment of Bar.
+message Bar {
+  message Inner {}
+  optional int32 c = 3;
+  oneof o {
+    string e = 5;
+    string d = 2;
+  }
+  option deprecated = true;
+  enum Kind {
+    B = 1;
+    A = 0;
+  }
+  optional int32 a = 1;
+  reserved 10;
+}
+import "other.proto";
+enum Color {
+  GREEN = 1;
+  option allow_alias = true;
+  RED = 0;
+  CRIMSON = 0;
+}
+service Alpha {}
+message Apple {}
+`,
+			wantOrder: []string{
+				"package foo",
+				"import other.proto",
+				"message Apple",
+				"message Bar (// Comment of Bar.)",
+				"  option deprecated",
+				"  reserved",
+				"  field a",
+				"  oneof o",
+				"    field d",
+				"    field e",
+				"  field c",
+				"  message Inner",
+				"  enum Kind",
+				"    value A",
+				"    value B",
+				"enum Color",
+				"  option allow_alias",
+				"  value RED",
+				"  value CRIMSON",
+				"  value GREEN",
+				"service Alpha",
+				"service Zoo",
+			},
+		},
+		{
+			name: "keeping the last comments at the end",
+			input: `
+syntax = "proto3";
+message B {}
+// Comment of A.
+message A {}
+// Last comment.
+`,
+			inputBodyIncludingComments: true,
+			wantOrder: []string{
+				"message A (// Comment of A.)",
+				"message B",
+				"comment // Last comment.",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(
+				lexer.NewLexer(strings.NewReader(test.input)),
+				parser.WithBodyIncludingComments(test.inputBodyIncludingComments),
+			)
+			got, err := p.ParseProto()
+			if err != nil {
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			parser.SortDeclarations(got)
+			gotOrder := declarationOrder(got.ProtoBody, "")
+			if !reflect.DeepEqual(gotOrder, test.wantOrder) {
+				t.Errorf("got %v, but want %v", gotOrder, test.wantOrder)
+			}
+
+			sorted := util_test.PrettyFormat(got)
+			parser.SortDeclarations(got)
+			if util_test.PrettyFormat(got) != sorted {
+				t.Errorf("got %v, but want %v", util_test.PrettyFormat(got), sorted)
+			}
+		})
+	}
+}
+
+func declarationOrder(body []parser.Visitee, indent string) []string {
+	var order []string
+	for _, element := range body {
+		switch e := element.(type) {
+		case *parser.Comment:
+			order = append(order, indent+"comment "+e.Raw)
+		case *parser.Package:
+			order = append(order, indent+"package "+e.Name)
+		case *parser.Import:
+			order = append(order, indent+"import "+strings.Trim(e.Location, `"`))
+		case *parser.Option:
+			order = append(order, indent+"option "+e.OptionName)
+		case *parser.Reserved:
+			order = append(order, indent+"reserved")
+		case *parser.Field:
+			order = append(order, indent+"field "+e.FieldName)
+		case *parser.Oneof:
+			order = append(order, indent+"oneof "+e.OneofName)
+			for _, field := range e.OneofFields {
+				order = append(order, indent+"  field "+field.FieldName)
+			}
+		case *parser.EnumField:
+			order = append(order, indent+"value "+e.Ident)
+		case *parser.Message:
+			name := indent + "message " + e.MessageName
+			if 0 < len(e.Comments) {
+				name += " (" + e.Comments[0].Raw + ")"
+			}
+			order = append(order, name)
+			order = append(order, declarationOrder(e.MessageBody, indent+"  ")...)
+		case *parser.Enum:
+			order = append(order, indent+"enum "+e.EnumName)
+			order = append(order, declarationOrder(e.EnumBody, indent+"  ")...)
+		case *parser.Service:
+			order = append(order, indent+"service "+e.ServiceName)
+		}
+	}
+	return order
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// UnquoteStrLit decodes the strLit, which is quoted by either '"' or '\'', as written in the source
+// like Option.Constant. The escapes are interpreted as protobuf does:
+//  - charEscape: "\a", "\b", "\f", "\n", "\r", "\t", "\v", "\\", "\'", "\"" and "\?"
+//  - hexEscape: "\x" followed by one or two hex digits, which is a byte
+//  - octEscape: "\" followed by one to three octal digits, which is a byte
+//  - unicodeEscape: "\u" followed by four hex digits or "\U" followed by eight, which is encoded in UTF-8
+// The other characters, including the multi-byte ones, are kept as is.
+// Since the byte escapes can produce invalid UTF-8, the result is the bytes of a bytes field as well.
+func UnquoteStrLit(s string) (string, error) {
+	if len(s) < 2 || (s[0] != '"' && s[0] != '\'') || s[0] != s[len(s)-1] {
+		return "", strconv.ErrSyntax
+	}
+	s = s[1 : len(s)-1]
+
+	var b strings.Builder
+	for len(s) > 0 {
+		if s[0] != '\\' {
+			b.WriteByte(s[0])
+			s = s[1:]
+			continue
+		}
+		if len(s) < 2 {
+			return "", strconv.ErrSyntax
+		}
+
+		escape := s[1]
+		s = s[2:]
+		switch escape {
+		case 'a':
+			b.WriteByte('\a')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'v':
+			b.WriteByte('\v')
+		case '\\', '\'', '"', '?':
+			b.WriteByte(escape)
+		case 'x', 'X':
+			digits := leadingDigits(s, 2, isHexDigit)
+			if digits == "" {
+				return "", strconv.ErrSyntax
+			}
+			n, _ := strconv.ParseUint(digits, 16, 8)
+			b.WriteByte(byte(n))
+			s = s[len(digits):]
+		case 'u', 'U':
+			size := 4
+			if escape == 'U' {
+				size = 8
+			}
+			digits := leadingDigits(s, size, isHexDigit)
+			if len(digits) != size {
+				return "", strconv.ErrSyntax
+			}
+			n, _ := strconv.ParseUint(digits, 16, 32)
+			if !utf8.ValidRune(rune(n)) {
+				return "", strconv.ErrSyntax
+			}
+			b.WriteRune(rune(n))
+			s = s[len(digits):]
+		default:
+			if !isOctalDigit(escape) {
+				return "", strconv.ErrSyntax
+			}
+			digits := string(escape) + leadingDigits(s, 2, isOctalDigit)
+			n, _ := strconv.ParseUint(digits, 8, 16)
+			if 0xff < n {
+				return "", strconv.ErrSyntax
+			}
+			b.WriteByte(byte(n))
+			s = s[len(digits)-1:]
+		}
+	}
+	return b.String(), nil
+}
+
+// leadingDigits returns the digits at the beginning of s, up to max.
+func leadingDigits(s string, max int, isDigit func(byte) bool) string {
+	i := 0
+	for i < len(s) && i < max && isDigit(s[i]) {
+		i++
+	}
+	return s[:i]
+}
+
+func isHexDigit(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+func isOctalDigit(c byte) bool {
+	return '0' <= c && c <= '7'
+}
@@ -0,0 +1,150 @@
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
+)
+
+func TestUnquoteStrLit(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantValue string
+		wantErr   bool
+	}{
+		{
+			name:      "decoding char escapes",
+			input:     `"\a\b\f\n\r\t\v\\\'\"\?"`,
+			wantValue: "\a\b\f\n\r\t\v\\'\"?",
+		},
+		{
+			name:      "decoding a single-quoted strLit",
+			input:     `'say "hi"'`,
+			wantValue: `say "hi"`,
+		},
+		{
+			name:      "decoding hex escapes with one or two digits",
+			input:     `"\x41\xfg\X7e"`,
+			wantValue: "A\x0fg~",
+		},
+		{
+			name:      "decoding octal escapes with one to three digits",
+			input:     `"\101\0\12x\1234"`,
+			wantValue: "A\x00\nxS4",
+		},
+		{
+			name:      "decoding unicode escapes",
+			input:     `"日\U0001F600"`,
+			wantValue: "日😀",
+		},
+		{
+			name:      "keeping multi-byte characters",
+			input:     `"日本 é"`,
+			wantValue: "日本 é",
+		},
+		{
+			name:    "decoding a short unicode escape",
+			input:   `"\u65e"`,
+			wantErr: true,
+		},
+		{
+			name:    "decoding an octal escape beyond a byte",
+			input:   `"\777"`,
+			wantErr: true,
+		},
+		{
+			name:    "decoding an unknown escape",
+			input:   `"\q"`,
+			wantErr: true,
+		},
+		{
+			name:    "decoding mismatched quotes",
+			input:   `"foo'`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parser.UnquoteStrLit(test.input)
+			switch {
+			case test.wantErr:
+				if err == nil {
+					t.Errorf("got err nil, but want err")
+				}
+				return
+			case !test.wantErr && err != nil:
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+			if got != test.wantValue {
+				t.Errorf("got %q, but want %q", got, test.wantValue)
+			}
+		})
+	}
+}
+
+func TestParser_ParseProto_multiByteStrLit(t *testing.T) {
+	input := `syntax = "proto3";
+// コメント é
+option a = "日本\n日";
+option b = 'é'; /* 😀 */ option c = "x";
+`
+	p := parser.NewParser(lexer.NewLexer(strings.NewReader(input)))
+	got, err := p.ParseProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		option       *parser.Option
+		wantConstant string
+		wantValue    string
+		wantPos      meta.Position
+	}{
+		{
+			name:         "the option after a multi-byte comment",
+			option:       got.ProtoBody[0].(*parser.Option),
+			wantConstant: `"日本\n日"`,
+			wantValue:    "日本\n日",
+			wantPos:      meta.Position{Offset: 38, Line: 3, Column: 1},
+		},
+		{
+			name:         "the option after a multi-byte strLit",
+			option:       got.ProtoBody[1].(*parser.Option),
+			wantConstant: `'é'`,
+			wantValue:    "é",
+			wantPos:      meta.Position{Offset: 64, Line: 4, Column: 1},
+		},
+		{
+			name:         "the option after a multi-byte block comment",
+			option:       got.ProtoBody[2].(*parser.Option),
+			wantConstant: `"x"`,
+			wantValue:    "x",
+			wantPos:      meta.Position{Offset: 92, Line: 4, Column: 25},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if test.option.Constant != test.wantConstant {
+				t.Errorf("got %q, but want %q", test.option.Constant, test.wantConstant)
+			}
+			if value, _ := test.option.StringValue(); value != test.wantValue {
+				t.Errorf("got %q, but want %q", value, test.wantValue)
+			}
+			if test.option.Meta.Pos != test.wantPos {
+				t.Errorf("got %#v, but want %#v", test.option.Meta.Pos, test.wantPos)
+			}
+			if start := test.option.Meta.Pos.Offset; !strings.HasPrefix(input[start:], "option") {
+				t.Errorf("got %q at the offset, but want the option", input[start:])
+			}
+		})
+	}
+}
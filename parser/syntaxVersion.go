@@ -0,0 +1,56 @@
+package parser
+
+// SyntaxVersion returns the protobuf version which the proto follows, either "proto2", "proto3" or "editions".
+// When the file declares neither syntax nor edition, it returns InferredSyntax if any.
+// Otherwise, it defaults to "proto2" as protoc does.
+func (p *Proto) SyntaxVersion() string {
+	switch {
+	case p.Syntax != nil:
+		return p.Syntax.ProtobufVersion
+	case p.Edition != nil:
+		return "editions"
+	case p.InferredSyntax != "":
+		return p.InferredSyntax
+	default:
+		return "proto2"
+	}
+}
+
+// inferSyntax infers the protobuf version from the constructs present in the proto body.
+// A required or optional label, a group, extensions or an explicit default suggests "proto2".
+// A field without any label suggests "proto3" unless any of the former is present.
+func inferSyntax(body []Visitee) string {
+	var hasProto2, hasProto3 bool
+	for _, element := range body {
+		if msg, ok := element.(*Message); ok {
+			proto2, proto3 := syntaxHintsOfMessageBody(msg.MessageBody)
+			hasProto2 = hasProto2 || proto2
+			hasProto3 = hasProto3 || proto3
+		}
+	}
+	if hasProto3 && !hasProto2 {
+		return "proto3"
+	}
+	return "proto2"
+}
+
+func syntaxHintsOfMessageBody(body []Visitee) (hasProto2 bool, hasProto3 bool) {
+	for _, element := range body {
+		switch e := element.(type) {
+		case *Field:
+			switch {
+			case e.IsRequired, e.IsOptional, hasDefaultFieldOption(e.FieldOptions):
+				hasProto2 = true
+			case !e.IsRepeated:
+				hasProto3 = true
+			}
+		case *GroupField, *Extensions:
+			hasProto2 = true
+		case *Message:
+			proto2, proto3 := syntaxHintsOfMessageBody(e.MessageBody)
+			hasProto2 = hasProto2 || proto2
+			hasProto3 = hasProto3 || proto3
+		}
+	}
+	return hasProto2, hasProto3
+}
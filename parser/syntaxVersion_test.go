@@ -0,0 +1,158 @@
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestProto_SyntaxVersion(t *testing.T) {
+	tests := []struct {
+		name                  string
+		input                 string
+		inputAutoDetectSyntax bool
+		wantSyntaxVersion     string
+		wantInferredSyntax    string
+		wantErr               bool
+	}{
+		{
+			name: "parsing a file with the proto3 syntax",
+			input: `syntax = "proto3";
+message SearchRequest {
+  required string query = 1;
+}
+`,
+			inputAutoDetectSyntax: true,
+			wantSyntaxVersion:     "proto3",
+		},
+		{
+			name: "parsing a file with the proto2 syntax",
+			input: `syntax = "proto2";
+message SearchRequest {
+  string query = 1;
+}
+`,
+			wantSyntaxVersion: "proto2",
+		},
+		{
+			name: "parsing a file with the edition",
+			input: `edition = "2023";
+message SearchRequest {
+  string query = 1;
+}
+`,
+			inputAutoDetectSyntax: true,
+			wantSyntaxVersion:     "editions",
+		},
+		{
+			name: "parsing a file without the syntax and the option",
+			input: `message SearchRequest {
+  string query = 1;
+}
+`,
+			wantErr: true,
+		},
+		{
+			name: "parsing a file without the syntax including proto2 labels",
+			input: `// A legacy file.
+package foo;
+message SearchRequest {
+  required string query = 1;
+  optional int32 page_number = 2;
+}
+`,
+			inputAutoDetectSyntax: true,
+			wantSyntaxVersion:     "proto2",
+			wantInferredSyntax:    "proto2",
+		},
+		{
+			name: "parsing a file without the syntax including a group in a nested message",
+			input: `message SearchRequest {
+  message Nested {
+    repeated group Result = 1 {
+      string url = 2;
+    }
+  }
+  string query = 3;
+}
+`,
+			inputAutoDetectSyntax: true,
+			wantSyntaxVersion:     "proto2",
+			wantInferredSyntax:    "proto2",
+		},
+		{
+			name: "parsing a file without the syntax including fields without labels",
+			input: `message SearchRequest {
+  string query = 1;
+  repeated int32 page_numbers = 2;
+  map<string, int32> counts = 3;
+}
+`,
+			inputAutoDetectSyntax: true,
+			wantSyntaxVersion:     "proto3",
+			wantInferredSyntax:    "proto3",
+		},
+		{
+			name: "parsing a file without the syntax and any hint",
+			input: `enum Corpus {
+  UNIVERSAL = 0;
+}
+`,
+			inputAutoDetectSyntax: true,
+			wantSyntaxVersion:     "proto2",
+			wantInferredSyntax:    "proto2",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(
+				lexer.NewLexer(strings.NewReader(test.input)),
+				parser.WithAutoDetectSyntax(test.inputAutoDetectSyntax),
+			)
+			got, err := p.ParseProto()
+			switch {
+			case test.wantErr:
+				if err == nil {
+					t.Errorf("got err nil, but want err")
+				}
+				return
+			case !test.wantErr && err != nil:
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			if got.SyntaxVersion() != test.wantSyntaxVersion {
+				t.Errorf("got %q, but want %q", got.SyntaxVersion(), test.wantSyntaxVersion)
+			}
+			if got.InferredSyntax != test.wantInferredSyntax {
+				t.Errorf("got %q, but want %q", got.InferredSyntax, test.wantInferredSyntax)
+			}
+		})
+	}
+}
+
+func TestParser_ParseProto_autoDetectSyntaxKeepsLeadingComments(t *testing.T) {
+	input := `// A legacy file.
+package foo;
+`
+	p := parser.NewParser(
+		lexer.NewLexer(strings.NewReader(input)),
+		parser.WithAutoDetectSyntax(true),
+	)
+	got, err := p.ParseProto()
+	if err != nil {
+		t.Fatalf("got err %v, but want nil", err)
+	}
+
+	pkg, ok := got.ProtoBody[0].(*parser.Package)
+	if !ok {
+		t.Fatalf("got %T, but want *parser.Package", got.ProtoBody[0])
+	}
+	if len(pkg.Comments) != 1 || pkg.Comments[0].Raw != "// A legacy file." {
+		t.Errorf("got %v, but want the leading comment", pkg.Comments)
+	}
+}
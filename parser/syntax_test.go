@@ -32,6 +32,11 @@ func TestParser_ParseSyntax(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 17,
+						Line:   1,
+						Column: 18,
+					},
 				},
 			},
 		},
@@ -46,6 +51,42 @@ func TestParser_ParseSyntax(t *testing.T) {
 						Line:   1,
 						Column: 1,
 					},
+					LastPos: meta.Position{
+						Offset: 17,
+						Line:   1,
+						Column: 18,
+					},
+				},
+			},
+		},
+		{
+			name:    "parsing an invalid version",
+			input:   `syntax = "proto4";`,
+			wantErr: true,
+		},
+		{
+			name:    "parsing an unquoted version",
+			input:   `syntax = proto3;`,
+			wantErr: true,
+		},
+		{
+			name: "parsing the position of the syntax keyword after blank lines",
+			input: `
+
+  syntax = "proto3";`,
+			wantSyntax: &parser.Syntax{
+				ProtobufVersion: "proto3",
+				Meta: meta.Meta{
+					Pos: meta.Position{
+						Offset: 4,
+						Line:   3,
+						Column: 3,
+					},
+					LastPos: meta.Position{
+						Offset: 21,
+						Line:   3,
+						Column: 20,
+					},
 				},
 			},
 		},
@@ -0,0 +1,103 @@
+package parser
+
+import "github.com/yoheimuta/go-protoparser/v4/parser/meta"
+
+const (
+	maxFieldNumber = 536870911
+
+	reservedFieldNumberBegin = 19000
+	reservedFieldNumberEnd   = 19999
+)
+
+// ValidateExtensions reports the extensions ranges in the message, including its nested ones, which overlap another
+// extensions range or a field number, or which fall within the range 19000 to 19999 reserved for the implementation.
+// An overlap between ranges is positioned at the later range, and an overlap with a field is positioned at the field.
+// Both refer to the position of the other element.
+//
+// See https://developers.google.com/protocol-buffers/docs/proto#extensions
+func ValidateExtensions(msg *Message) []error {
+	return validateExtensionsBody(msg.MessageBody)
+}
+
+// extensionsRange is a range declared by an extensions statement.
+type extensionsRange struct {
+	begin int
+	end   int
+	text  string
+	pos   meta.Position
+}
+
+func validateExtensionsBody(body []Visitee) []error {
+	var errs []error
+
+	var ranges []extensionsRange
+	for _, element := range body {
+		extensions, ok := element.(*Extensions)
+		if !ok {
+			continue
+		}
+		for _, r := range extensions.Ranges {
+			current, err := newExtensionsRange(r, extensions.Meta.Pos)
+			if err != nil {
+				continue
+			}
+
+			if reservedFieldNumberBegin <= current.begin && current.end <= reservedFieldNumberEnd {
+				errs = append(errs, newValidationError(
+					current.pos,
+					"extensions range %s falls within the reserved range %d to %d",
+					current.text, reservedFieldNumberBegin, reservedFieldNumberEnd,
+				))
+			}
+			for _, other := range ranges {
+				if current.begin <= other.end && other.begin <= current.end {
+					errs = append(errs, newValidationError(
+						current.pos,
+						"extensions range %s overlaps the range %s at %s",
+						current.text, other.text, other.pos,
+					))
+				}
+			}
+			ranges = append(ranges, current)
+		}
+	}
+
+	for _, field := range numberedFields(body) {
+		for _, r := range ranges {
+			if r.begin <= field.number && field.number <= r.end {
+				errs = append(errs, newValidationError(
+					field.pos,
+					"field %q number %d overlaps the extensions range %s at %s",
+					field.name, field.number, r.text, r.pos,
+				))
+			}
+		}
+	}
+
+	for _, element := range body {
+		switch e := element.(type) {
+		case *Message:
+			errs = append(errs, validateExtensionsBody(e.MessageBody)...)
+		case *GroupField:
+			errs = append(errs, validateExtensionsBody(e.MessageBody)...)
+		}
+	}
+	return errs
+}
+
+func newExtensionsRange(r *Range, pos meta.Position) (extensionsRange, error) {
+	begin, end, err := r.Bounds()
+	if err != nil {
+		return extensionsRange{}, err
+	}
+	text := r.Begin
+	if r.End != "" {
+		text += " to " + r.End
+	}
+	return extensionsRange{
+		begin: begin,
+		end:   end,
+		text:  text,
+		pos:   pos,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestValidateExtensions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantErrs []string
+	}{
+		{
+			name: "validating valid ranges",
+			input: `message Foo {
+  optional int32 a = 1;
+  extensions 100 to 199;
+  extensions 200, 300 to max;
+}`,
+		},
+		{
+			name: "validating overlapping ranges",
+			input: `message Foo {
+  extensions 100 to 199;
+  extensions 150 to 250, 1000;
+  extensions 1000 to max;
+}`,
+			wantErrs: []string{
+				`<input>:3:3: extensions range 150 to 250 overlaps the range 100 to 199 at <input>:2:3`,
+				`<input>:4:3: extensions range 1000 to max overlaps the range 1000 at <input>:3:3`,
+			},
+		},
+		{
+			name: "validating ranges overlapping fields",
+			input: `message Foo {
+  optional int32 a = 150;
+  extensions 100 to 199;
+  oneof b {
+    string c = 120;
+  }
+  message Bar {
+    extensions 10;
+    optional int32 d = 10;
+  }
+}`,
+			wantErrs: []string{
+				`<input>:2:3: field "a" number 150 overlaps the extensions range 100 to 199 at <input>:3:3`,
+				`<input>:5:5: field "c" number 120 overlaps the extensions range 100 to 199 at <input>:3:3`,
+				`<input>:9:5: field "d" number 10 overlaps the extensions range 10 at <input>:8:5`,
+			},
+		},
+		{
+			name: "validating ranges within the reserved range",
+			input: `message Foo {
+  extensions 19000 to 19999;
+  extensions 18000 to max;
+}`,
+			wantErrs: []string{
+				`<input>:2:3: extensions range 19000 to 19999 falls within the reserved range 19000 to 19999`,
+				`<input>:3:3: extensions range 18000 to max overlaps the range 19000 to 19999 at <input>:2:3`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			msg, err := p.ParseMessage()
+			if err != nil {
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			var got []string
+			for _, e := range parser.ValidateExtensions(msg) {
+				got = append(got, e.Error())
+			}
+			if !reflect.DeepEqual(got, test.wantErrs) {
+				t.Errorf("got %v, but want %v", got, test.wantErrs)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package parser
+
+// ValidateFieldNumbers reports the fields in the message, including its nested ones, whose number is outside
+// the range 1 to 536870911, falls within the range 19000 to 19999 reserved for the implementation,
+// or reuses a number of another field in the same message. Oneof fields share the number space of the enclosing message,
+// so a oneof field can't reuse the number of a field outside the oneof and vice versa.
+// Each error is positioned at the later field and refers to the position of the earlier one.
+//
+// See https://developers.google.com/protocol-buffers/docs/proto3#assigning_field_numbers
+func ValidateFieldNumbers(msg *Message) []error {
+	return validateFieldNumbersBody(msg.MessageBody)
+}
+
+func validateFieldNumbersBody(body []Visitee) []error {
+	var errs []error
+
+	seen := make(map[int]numberedField)
+	for _, field := range numberedFields(body) {
+		if field.number < 1 || maxFieldNumber < field.number {
+			errs = append(errs, newValidationError(
+				field.pos,
+				"field %q number %d is out of the range 1 to %d",
+				field.name, field.number, maxFieldNumber,
+			))
+		} else if reservedFieldNumberBegin <= field.number && field.number <= reservedFieldNumberEnd {
+			errs = append(errs, newValidationError(
+				field.pos,
+				"field %q number %d falls within the reserved range %d to %d",
+				field.name, field.number, reservedFieldNumberBegin, reservedFieldNumberEnd,
+			))
+		}
+
+		if first, ok := seen[field.number]; ok {
+			errs = append(errs, newValidationError(
+				field.pos,
+				"field %q reuses the number %d of field %q at %s",
+				field.name, field.number, first.name, first.pos,
+			))
+			continue
+		}
+		seen[field.number] = field
+	}
+
+	for _, element := range body {
+		switch e := element.(type) {
+		case *Message:
+			errs = append(errs, validateFieldNumbersBody(e.MessageBody)...)
+		case *GroupField:
+			errs = append(errs, validateFieldNumbersBody(e.MessageBody)...)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,131 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestValidateFieldNumbers(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantErrs []string
+	}{
+		{
+			name: "validating a valid message",
+			input: `message Foo {
+  int32 a = 1;
+  oneof b {
+    string c = 2;
+    string d = 3;
+  }
+  map<string, int32> e = 4;
+  message Bar {
+    int32 a = 1;
+  }
+}`,
+		},
+		{
+			name: "validating a oneof field which reuses the number of a regular field",
+			input: `message Foo {
+  int32 a = 1;
+  oneof b {
+    string c = 2;
+    string d = 1;
+  }
+}`,
+			wantErrs: []string{
+				`<input>:5:5: field "d" reuses the number 1 of field "a" at <input>:2:3`,
+			},
+		},
+		{
+			name: "validating a regular field which reuses the number of a oneof field",
+			input: `message Foo {
+  oneof b {
+    string c = 2;
+  }
+  int32 a = 2;
+}`,
+			wantErrs: []string{
+				`<input>:5:3: field "a" reuses the number 2 of field "c" at <input>:3:5`,
+			},
+		},
+		{
+			name: "validating fields which reuse numbers in a nested message and across oneofs",
+			input: `message Foo {
+  map<string, int32> m = 1;
+  message Bar {
+    int32 a = 1;
+    int32 b = 1;
+  }
+  oneof c {
+    string d = 2;
+  }
+  oneof e {
+    string f = 2;
+  }
+}`,
+			wantErrs: []string{
+				`<input>:11:5: field "f" reuses the number 2 of field "d" at <input>:8:5`,
+				`<input>:5:5: field "b" reuses the number 1 of field "a" at <input>:4:5`,
+			},
+		},
+		{
+			name: "validating fields whose numbers are out of the legal range",
+			input: `message Foo {
+  int32 a = 0;
+  int32 b = 536870911;
+  int32 c = 536870912;
+  oneof d {
+    string e = 19000;
+  }
+  message Bar {
+    int32 f = 19999;
+    int32 g = 20000;
+  }
+}`,
+			wantErrs: []string{
+				`<input>:2:3: field "a" number 0 is out of the range 1 to 536870911`,
+				`<input>:4:3: field "c" number 536870912 is out of the range 1 to 536870911`,
+				`<input>:6:5: field "e" number 19000 falls within the reserved range 19000 to 19999`,
+				`<input>:9:5: field "f" number 19999 falls within the reserved range 19000 to 19999`,
+			},
+		},
+		{
+			name: "validating a field whose number is out of the range and reused",
+			input: `message Foo {
+  int32 a = 0x0;
+  int32 b = 0;
+}`,
+			wantErrs: []string{
+				`<input>:2:3: field "a" number 0 is out of the range 1 to 536870911`,
+				`<input>:3:3: field "b" number 0 is out of the range 1 to 536870911`,
+				`<input>:3:3: field "b" reuses the number 0 of field "a" at <input>:2:3`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			msg, err := p.ParseMessage()
+			if err != nil {
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			var got []string
+			for _, e := range parser.ValidateFieldNumbers(msg) {
+				got = append(got, e.Error())
+			}
+			if !reflect.DeepEqual(got, test.wantErrs) {
+				t.Errorf("got %v, but want %v", got, test.wantErrs)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package parser
+
+// ValidateProto3Message reports the constructs which the message, including its nested ones, can't use in proto3.
+// That is, group fields, extensions, required labels and default field options.
+// Note that extends aren't reported because proto3 uses them to define custom options.
+//
+// See https://developers.google.com/protocol-buffers/docs/proto3
+func ValidateProto3Message(msg *Message) []error {
+	return validateProto3MessageBody(msg.MessageBody)
+}
+
+func validateProto3MessageBody(body []Visitee) []error {
+	var errs []error
+	for _, element := range body {
+		switch e := element.(type) {
+		case *Field:
+			if e.IsRequired {
+				errs = append(errs, newValidationError(e.Meta.Pos, "field %q must not be required in proto3", e.FieldName))
+			}
+			if hasDefaultFieldOption(e.FieldOptions) {
+				errs = append(errs, newValidationError(e.Meta.Pos, "field %q must not have an explicit default in proto3", e.FieldName))
+			}
+		case *OneofField:
+			if hasDefaultFieldOption(e.FieldOptions) {
+				errs = append(errs, newValidationError(e.Meta.Pos, "field %q must not have an explicit default in proto3", e.FieldName))
+			}
+		case *Oneof:
+			for _, field := range e.OneofFields {
+				errs = append(errs, validateProto3MessageBody([]Visitee{field})...)
+			}
+		case *GroupField:
+			errs = append(errs, newValidationError(e.Meta.Pos, "group %q must not be used in proto3", e.GroupName))
+			errs = append(errs, validateProto3MessageBody(e.MessageBody)...)
+		case *Extensions:
+			errs = append(errs, newValidationError(e.Meta.Pos, "extensions must not be used in proto3"))
+		case *Message:
+			errs = append(errs, validateProto3MessageBody(e.MessageBody)...)
+		}
+	}
+	return errs
+}
+
+func hasDefaultFieldOption(options []*FieldOption) bool {
+	for _, option := range options {
+		if option.OptionName == "default" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yoheimuta/go-protoparser/v4/internal/lexer"
+	"github.com/yoheimuta/go-protoparser/v4/parser"
+)
+
+func TestValidateProto3Message(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantErrs []string
+	}{
+		{
+			name: "validating a valid message",
+			input: `message Foo {
+  optional int32 a = 1;
+  repeated string b = 2 [packed = true];
+  oneof c {
+    string d = 3;
+  }
+  map<string, int32> e = 4;
+}`,
+		},
+		{
+			name: "validating a group field",
+			input: `message Foo {
+  repeated group Result = 1 {
+    string url = 2;
+  }
+}`,
+			wantErrs: []string{
+				`<input>:2:3: group "Result" must not be used in proto3`,
+			},
+		},
+		{
+			name: "validating extensions",
+			input: `message Foo {
+  extensions 100 to 199;
+}`,
+			wantErrs: []string{
+				`<input>:2:3: extensions must not be used in proto3`,
+			},
+		},
+		{
+			name: "validating a required label",
+			input: `message Foo {
+  required int32 a = 1;
+}`,
+			wantErrs: []string{
+				`<input>:2:3: field "a" must not be required in proto3`,
+			},
+		},
+		{
+			name: "validating an explicit default",
+			input: `message Foo {
+  int32 a = 1 [default = 10];
+  oneof b {
+    string c = 2 [default = "c"];
+  }
+}`,
+			wantErrs: []string{
+				`<input>:2:3: field "a" must not have an explicit default in proto3`,
+				`<input>:4:5: field "c" must not have an explicit default in proto3`,
+			},
+		},
+		{
+			name: "validating a nested message",
+			input: `message Foo {
+  message Bar {
+    required int32 a = 1;
+  }
+}`,
+			wantErrs: []string{
+				`<input>:3:5: field "a" must not be required in proto3`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer(strings.NewReader(test.input)))
+			msg, err := p.ParseMessage()
+			if err != nil {
+				t.Errorf("got err %v, but want nil", err)
+				return
+			}
+
+			var got []string
+			for _, e := range parser.ValidateProto3Message(msg) {
+				got = append(got, e.Error())
+			}
+			if !reflect.DeepEqual(got, test.wantErrs) {
+				t.Errorf("got %v, but want %v", got, test.wantErrs)
+			}
+		})
+	}
+}
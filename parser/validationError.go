@@ -0,0 +1,26 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/yoheimuta/go-protoparser/v4/parser/meta"
+)
+
+// ValidationError is an error reported by the validation passes over the parsed elements.
+type ValidationError struct {
+	// Pos is the source position of the offending element.
+	Pos meta.Position
+	// Message describes the violation.
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Message)
+}
+
+func newValidationError(pos meta.Position, format string, a ...interface{}) *ValidationError {
+	return &ValidationError{
+		Pos:     pos,
+		Message: fmt.Sprintf(format, a...),
+	}
+}
@@ -10,10 +10,12 @@ import (
 
 // ParseConfig is a config for parser.
 type ParseConfig struct {
-	debug                 bool
-	permissive            bool
-	bodyIncludingComments bool
-	filename              string
+	debug                    bool
+	permissive               bool
+	bodyIncludingComments    bool
+	commentLeadingWhitespace bool
+	autoDetectSyntax         bool
+	filename                 string
 }
 
 // Option is an option for ParseConfig.
@@ -41,6 +43,21 @@ func WithBodyIncludingComments(bodyIncludingComments bool) Option {
 	}
 }
 
+// WithCommentLeadingWhitespace is an option to capture the whitespace placed before each comment on the same line.
+func WithCommentLeadingWhitespace(commentLeadingWhitespace bool) Option {
+	return func(c *ParseConfig) {
+		c.commentLeadingWhitespace = commentLeadingWhitespace
+	}
+}
+
+// WithAutoDetectSyntax is an option to allow a file which lacks the syntax and the edition.
+// The inferred protobuf version is available through Proto.SyntaxVersion.
+func WithAutoDetectSyntax(autoDetectSyntax bool) Option {
+	return func(c *ParseConfig) {
+		c.autoDetectSyntax = autoDetectSyntax
+	}
+}
+
 // WithFilename is an option to set filename to the Position.
 func WithFilename(filename string) Option {
 	return func(c *ParseConfig) {
@@ -65,6 +82,8 @@ func Parse(input io.Reader, options ...Option) (*parser.Proto, error) {
 		),
 		parser.WithPermissive(config.permissive),
 		parser.WithBodyIncludingComments(config.bodyIncludingComments),
+		parser.WithCommentLeadingWhitespace(config.commentLeadingWhitespace),
+		parser.WithAutoDetectSyntax(config.autoDetectSyntax),
 	)
 	return p.ParseProto()
 }